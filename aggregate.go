@@ -0,0 +1,162 @@
+package nullint64
+
+import "sort"
+
+// Aggregator computes running aggregates over a stream of Int64 values
+// without materializing them. Null values are skipped for Sum, Min, Max and
+// Avg, as SQL aggregates do. The zero value is ready to use.
+//
+// Once the sum overflows int64, Err reports ErrOverflow and Sum and Avg are
+// no longer meaningful; Count, Min and Max are unaffected.
+type Aggregator struct {
+	// CountNulls includes null values in Count when true.
+	CountNulls bool
+
+	count    int
+	nulls    int
+	sum      int64
+	min      int64
+	max      int64
+	overflow bool
+}
+
+// Add feeds v into the aggregates.
+func (a *Aggregator) Add(v Int64) {
+	if !v.Valid {
+		a.nulls++
+		return
+	}
+	if a.count == 0 || v.Int64 < a.min {
+		a.min = v.Int64
+	}
+	if a.count == 0 || v.Int64 > a.max {
+		a.max = v.Int64
+	}
+	if _, err := Int64From(a.sum).AddChecked(v); err != nil {
+		a.overflow = true
+	}
+	a.sum += v.Int64
+	a.count++
+}
+
+// Err returns ErrOverflow if the sum of the values added has overflowed
+// int64, and nil otherwise.
+func (a *Aggregator) Err() error {
+	if a.overflow {
+		return ErrOverflow
+	}
+	return nil
+}
+
+// Count returns the number of valid values added, plus the number of nulls
+// if CountNulls is set.
+func (a *Aggregator) Count() int {
+	if a.CountNulls {
+		return a.count + a.nulls
+	}
+	return a.count
+}
+
+// Sum returns the sum of all valid values, or null if there were none. It
+// is not meaningful once Err reports an overflow.
+func (a *Aggregator) Sum() Int64 {
+	if a.count == 0 {
+		return NewInt64(0, false)
+	}
+	return Int64From(a.sum)
+}
+
+// Min returns the smallest valid value, or null if there were none.
+func (a *Aggregator) Min() Int64 {
+	if a.count == 0 {
+		return NewInt64(0, false)
+	}
+	return Int64From(a.min)
+}
+
+// Max returns the largest valid value, or null if there were none.
+func (a *Aggregator) Max() Int64 {
+	if a.count == 0 {
+		return NewInt64(0, false)
+	}
+	return Int64From(a.max)
+}
+
+// Avg returns the mean of all valid values, or 0 if there were none. Nulls
+// never contribute to the mean, even when CountNulls is set. It is not
+// meaningful once Err reports an overflow.
+func (a *Aggregator) Avg() float64 {
+	if a.count == 0 {
+		return 0
+	}
+	return float64(a.sum) / float64(a.count)
+}
+
+// Bucketize counts the valid values falling into each half-open bin
+// [edges[k], edges[k+1]). edges must be sorted in ascending order; values
+// outside [edges[0], edges[len(edges)-1]) are not counted in any bucket.
+// Nulls are tallied separately in nullCount.
+func Bucketize(values []Int64, edges []int64) (buckets []int, nullCount int) {
+	if len(edges) > 1 {
+		buckets = make([]int, len(edges)-1)
+	}
+	for _, v := range values {
+		if !v.Valid {
+			nullCount++
+			continue
+		}
+		// Index of the first edge greater than the value; the bin is the
+		// one ending at that edge.
+		k := sort.Search(len(edges), func(n int) bool { return edges[n] > v.Int64 })
+		if k == 0 || k == len(edges) {
+			continue
+		}
+		buckets[k-1]++
+	}
+	return buckets, nullCount
+}
+
+// aggregate feeds every element of vs into a new Aggregator.
+func aggregate(vs []Int64) *Aggregator {
+	var a Aggregator
+	for _, v := range vs {
+		a.Add(v)
+	}
+	return &a
+}
+
+// SumSlice returns the sum of the valid values in vs, or null if there are
+// none. It is named to avoid clashing with the Min and Max validation
+// rules, as are MinSlice, MaxSlice and AvgSlice. Overflow isn't reported;
+// use an Aggregator and its Err method to detect it, as for AvgSlice.
+func SumSlice(vs []Int64) Int64 {
+	return aggregate(vs).Sum()
+}
+
+// MinSlice returns the smallest valid value in vs, or null if there are
+// none.
+func MinSlice(vs []Int64) Int64 {
+	return aggregate(vs).Min()
+}
+
+// MaxSlice returns the largest valid value in vs, or null if there are
+// none.
+func MaxSlice(vs []Int64) Int64 {
+	return aggregate(vs).Max()
+}
+
+// AvgSlice returns the mean of the valid values in vs, or null if there
+// are none.
+func AvgSlice(vs []Int64) Float64 {
+	a := aggregate(vs)
+	if a.Count() == 0 {
+		return NewFloat64(0, false)
+	}
+	return Float64From(a.Avg())
+}
+
+// Compact returns the valid values in vs, dropping nulls. It is
+// ValueSlice(vs, SkipNulls).
+func Compact(vs []Int64) []int64 {
+	return ValueSlice(vs, SkipNulls)
+}
@@ -0,0 +1,87 @@
+package nullint64
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAggregator(t *testing.T) {
+	var a Aggregator
+	for _, v := range []Int64{Int64From(3), NewInt64(0, false), Int64From(-1), Int64From(4)} {
+		a.Add(v)
+	}
+	if got := a.Count(); got != 3 {
+		t.Errorf("Count() = %d, want 3", got)
+	}
+	if got := a.Sum(); got != Int64From(6) {
+		t.Errorf("Sum() = %+v, want 6", got)
+	}
+	if got := a.Min(); got != Int64From(-1) {
+		t.Errorf("Min() = %+v, want -1", got)
+	}
+	if got := a.Max(); got != Int64From(4) {
+		t.Errorf("Max() = %+v, want 4", got)
+	}
+	if got := a.Avg(); got != 2 {
+		t.Errorf("Avg() = %v, want 2", got)
+	}
+	if err := a.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+
+	a.CountNulls = true
+	if got := a.Count(); got != 4 {
+		t.Errorf("Count() with CountNulls = %d, want 4", got)
+	}
+}
+
+func TestAggregatorEmpty(t *testing.T) {
+	var a Aggregator
+	a.Add(NewInt64(0, false))
+	if a.Sum().Valid || a.Min().Valid || a.Max().Valid || a.Avg() != 0 {
+		t.Errorf("empty aggregates = %+v, %+v, %+v, %v", a.Sum(), a.Min(), a.Max(), a.Avg())
+	}
+}
+
+func TestAggregatorOverflow(t *testing.T) {
+	var a Aggregator
+	a.Add(Int64From(math.MaxInt64))
+	if err := a.Err(); err != nil {
+		t.Fatalf("Err() before overflow = %v", err)
+	}
+	a.Add(Int64From(1))
+	if err := a.Err(); err != ErrOverflow {
+		t.Errorf("Err() = %v, want ErrOverflow", err)
+	}
+	a.Add(Int64From(-1))
+	if err := a.Err(); err != ErrOverflow {
+		t.Errorf("Err() after wrapping back = %v, want ErrOverflow", err)
+	}
+	if got := a.Max(); got != Int64From(math.MaxInt64) {
+		t.Errorf("Max() = %+v", got)
+	}
+}
+
+func TestBucketize(t *testing.T) {
+	values := []Int64{Int64From(0), Int64From(5), Int64From(9), Int64From(10), Int64From(-1), Int64From(25), NewInt64(0, false), {}}
+	tests := []struct {
+		name      string
+		edges     []int64
+		want      []int
+		wantNulls int
+	}{
+		{"two bins", []int64{0, 10, 20}, []int{3, 1}, 2},
+		{"one bin", []int64{0, 100}, []int{5}, 2},
+		{"no bins", []int64{0}, nil, 2},
+		{"no edges", nil, nil, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, nulls := Bucketize(values, tt.edges)
+			if !reflect.DeepEqual(got, tt.want) || nulls != tt.wantNulls {
+				t.Errorf("Bucketize = %v, %d, want %v, %d", got, nulls, tt.want, tt.wantNulls)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package nullint64
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrOverflow is returned by the checked arithmetic methods when the result
+// doesn't fit in an int64, and wrapped in a *NumberError by UnmarshalJSON
+// under StrictJSON for a number outside the range of int64.
+var ErrOverflow = errors.New("nullint64: integer overflow")
+
+// Add returns i + other, wrapping on overflow as int64 arithmetic does. The
+// result is null if either operand is null; use AddChecked to detect
+// overflow.
+func (i Int64) Add(other Int64) Int64 {
+	if !i.Valid || !other.Valid {
+		return NewInt64(0, false)
+	}
+	return Int64From(i.Int64 + other.Int64)
+}
+
+// Sub returns i - other, wrapping on overflow. The result is null if
+// either operand is null.
+func (i Int64) Sub(other Int64) Int64 {
+	if !i.Valid || !other.Valid {
+		return NewInt64(0, false)
+	}
+	return Int64From(i.Int64 - other.Int64)
+}
+
+// Mul returns i * other, wrapping on overflow. The result is null if
+// either operand is null.
+func (i Int64) Mul(other Int64) Int64 {
+	if !i.Valid || !other.Valid {
+		return NewInt64(0, false)
+	}
+	return Int64From(i.Int64 * other.Int64)
+}
+
+// AddChecked returns i + other, or ErrOverflow if the sum overflows. The
+// result is null, with no error, if either operand is null.
+func (i Int64) AddChecked(other Int64) (Int64, error) {
+	if !i.Valid || !other.Valid {
+		return NewInt64(0, false), nil
+	}
+	a, b := i.Int64, other.Int64
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return Int64{}, ErrOverflow
+	}
+	return Int64From(a + b), nil
+}
+
+// SubChecked returns i - other, or ErrOverflow if the difference overflows.
+// The result is null, with no error, if either operand is null.
+func (i Int64) SubChecked(other Int64) (Int64, error) {
+	if !i.Valid || !other.Valid {
+		return NewInt64(0, false), nil
+	}
+	a, b := i.Int64, other.Int64
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return Int64{}, ErrOverflow
+	}
+	return Int64From(a - b), nil
+}
+
+// MulChecked returns i * other, or ErrOverflow if the product overflows.
+// The result is null, with no error, if either operand is null.
+func (i Int64) MulChecked(other Int64) (Int64, error) {
+	if !i.Valid || !other.Valid {
+		return NewInt64(0, false), nil
+	}
+	a, b := i.Int64, other.Int64
+	if a == 0 || b == 0 {
+		return Int64From(0), nil
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return Int64{}, ErrOverflow
+	}
+	return Int64From(c), nil
+}
+
+// RatioOf returns i / total as a float64, for percentages and the like. ok
+// is false if either value is null or total is zero.
+func (i Int64) RatioOf(total Int64) (float64, bool) {
+	if !i.Valid || !total.Valid || total.Int64 == 0 {
+		return 0, false
+	}
+	return float64(i.Int64) / float64(total.Int64), true
+}
@@ -0,0 +1,76 @@
+package nullint64
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckedArithmetic(t *testing.T) {
+	null := NewInt64(0, false)
+	type op func(Int64, Int64) (Int64, error)
+	add := func(a, b Int64) (Int64, error) { return a.AddChecked(b) }
+	sub := func(a, b Int64) (Int64, error) { return a.SubChecked(b) }
+	mul := func(a, b Int64) (Int64, error) { return a.MulChecked(b) }
+	tests := []struct {
+		name    string
+		op      op
+		a, b    Int64
+		want    Int64
+		wantErr error
+	}{
+		{"add", add, Int64From(2), Int64From(3), Int64From(5), nil},
+		{"add max", add, Int64From(math.MaxInt64 - 1), Int64From(1), Int64From(math.MaxInt64), nil},
+		{"add overflow", add, Int64From(math.MaxInt64), Int64From(1), Int64{}, ErrOverflow},
+		{"add underflow", add, Int64From(math.MinInt64), Int64From(-1), Int64{}, ErrOverflow},
+		{"add null", add, null, Int64From(1), null, nil},
+		{"sub", sub, Int64From(2), Int64From(3), Int64From(-1), nil},
+		{"sub overflow", sub, Int64From(math.MaxInt64), Int64From(-1), Int64{}, ErrOverflow},
+		{"sub underflow", sub, Int64From(math.MinInt64), Int64From(1), Int64{}, ErrOverflow},
+		{"sub null", sub, Int64From(1), null, null, nil},
+		{"mul", mul, Int64From(-4), Int64From(3), Int64From(-12), nil},
+		{"mul zero", mul, Int64From(0), Int64From(math.MinInt64), Int64From(0), nil},
+		{"mul overflow", mul, Int64From(math.MaxInt64/2 + 1), Int64From(2), Int64{}, ErrOverflow},
+		{"mul min by -1", mul, Int64From(math.MinInt64), Int64From(-1), Int64{}, ErrOverflow},
+		{"mul -1 by min", mul, Int64From(-1), Int64From(math.MinInt64), Int64{}, ErrOverflow},
+		{"mul null", mul, null, null, null, nil},
+	}
+	for _, tt := range tests {
+		got, err := tt.op(tt.a, tt.b)
+		if err != tt.wantErr || got != tt.want {
+			t.Errorf("%s: = %+v, %v, want %+v, %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWrappingArithmetic(t *testing.T) {
+	if got := Int64From(math.MaxInt64).Add(Int64From(1)); got != Int64From(math.MinInt64) {
+		t.Errorf("Add wrap = %+v", got)
+	}
+	if got := Int64From(5).Sub(Int64From(7)); got != Int64From(-2) {
+		t.Errorf("Sub = %+v", got)
+	}
+	if got := Int64From(5).Mul(NewInt64(0, false)); got.Valid {
+		t.Errorf("Mul with null = %+v, want null", got)
+	}
+}
+
+func TestRatioOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		i, total Int64
+		want     float64
+		wantOK   bool
+	}{
+		{"quarter", Int64From(1), Int64From(4), 0.25, true},
+		{"negative", Int64From(-3), Int64From(2), -1.5, true},
+		{"zero total", Int64From(1), Int64From(0), 0, false},
+		{"null part", NewInt64(0, false), Int64From(4), 0, false},
+		{"null total", Int64From(1), Int64{}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.i.RatioOf(tt.total)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: RatioOf = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
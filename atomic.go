@@ -0,0 +1,59 @@
+package nullint64
+
+import "sync"
+
+// AtomicInt64 is an Int64 that is safe for concurrent use, such as a
+// shared nullable gauge. Its methods read and write Int64, Valid and Set
+// together, so no reader sees one field updated without the others. The
+// zero value holds an unset Int64, and an AtomicInt64 must not be copied
+// after first use.
+type AtomicInt64 struct {
+	mu sync.Mutex
+	v  Int64
+}
+
+// Load returns the current value.
+func (a *AtomicInt64) Load() Int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.v
+}
+
+// Store replaces the current value with i.
+func (a *AtomicInt64) Store(i Int64) {
+	a.mu.Lock()
+	a.v = i
+	a.mu.Unlock()
+}
+
+// Swap replaces the current value with i and returns the previous one.
+func (a *AtomicInt64) Swap(i Int64) Int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	old := a.v
+	a.v = i
+	return old
+}
+
+// SetValid stores n as a valid value.
+func (a *AtomicInt64) SetValid(n int64) {
+	a.Store(Int64From(n))
+}
+
+// SetNull stores an explicit null.
+func (a *AtomicInt64) SetNull() {
+	a.Store(NewInt64(0, false))
+}
+
+// CompareAndSwap stores new and reports true if the current value equals
+// old in all of Int64, Valid and Set; otherwise it leaves the value
+// unchanged and reports false.
+func (a *AtomicInt64) CompareAndSwap(old, new Int64) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.v != old {
+		return false
+	}
+	a.v = new
+	return true
+}
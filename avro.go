@@ -0,0 +1,58 @@
+package nullint64
+
+import "fmt"
+
+// AvroSchema is the Avro schema of the nullable long union Int64 maps to.
+// Declare fields with it and a null default so the field may be absent:
+//
+//	{"name": "n", "type": ["null", "long"], "default": null}
+const AvroSchema = `["null","long"]`
+
+// ToAvroUnion returns this Int64 in the generic form Avro libraries such as
+// linkedin/goavro and hamba/avro take for a ["null","long"] union: nil for
+// null, and a map from the branch name "long" to the value otherwise. Avro
+// has no notion of unset, so an unset Int64 also encodes as nil.
+func (i Int64) ToAvroUnion() interface{} {
+	if !i.Valid {
+		return nil
+	}
+	return map[string]interface{}{"long": i.Int64}
+}
+
+// FromAvroUnion converts a decoded ["null","long"] union to a set Int64. It
+// accepts nil as null, a single-entry map from the branch name "long" or
+// "int" to the value as goavro decodes unions, and a bare or pointer
+// integer as hamba/avro decodes them.
+func FromAvroUnion(v interface{}) (Int64, error) {
+	if m, ok := v.(map[string]interface{}); ok {
+		if len(m) != 1 {
+			return Int64{}, fmt.Errorf("nullint64: Avro union map has %d branches, want 1", len(m))
+		}
+		for name, x := range m {
+			if name != "long" && name != "int" {
+				return Int64{}, fmt.Errorf("nullint64: cannot convert Avro union branch %q to Int64", name)
+			}
+			v = x
+		}
+	}
+
+	switch x := v.(type) {
+	case nil:
+		return NewInt64(0, false), nil
+	case int64:
+		return Int64From(x), nil
+	case int32:
+		return Int64From(int64(x)), nil
+	case int:
+		return Int64From(int64(x)), nil
+	case *int64:
+		return Int64FromPtr(x), nil
+	case *int32:
+		if x == nil {
+			return NewInt64(0, false), nil
+		}
+		return Int64From(int64(*x)), nil
+	default:
+		return Int64{}, fmt.Errorf("nullint64: cannot convert Avro value of type %T to Int64", v)
+	}
+}
@@ -0,0 +1,175 @@
+package nullint64
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/ccakes/nullint64/internal/convert"
+)
+
+// BigInt is a nullable arbitrary-precision integer with the same
+// Valid/Set semantics as Int64, for NUMERIC and DECIMAL columns of scale
+// zero that overflow int64. BigInt is nil unless Valid. The methods never
+// modify the big.Int a BigInt points to, except that unmarshaling and
+// scanning allocate a new one.
+type BigInt struct {
+	BigInt *big.Int
+	Valid  bool
+	Set    bool
+}
+
+// NewBigInt creates a new BigInt
+func NewBigInt(v *big.Int, valid bool) BigInt {
+	if !valid {
+		v = nil
+	}
+	return BigInt{
+		BigInt: v,
+		Valid:  valid,
+		Set:    true,
+	}
+}
+
+// BigIntFrom creates a new BigInt that will be null if v is nil.
+func BigIntFrom(v *big.Int) BigInt {
+	return NewBigInt(v, v != nil)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (b BigInt) IsValid() bool {
+	return b.Set && b.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (b BigInt) IsSet() bool {
+	return b.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON integer of
+// any size, bare or quoted, and null.
+func (b *BigInt) UnmarshalJSON(data []byte) error {
+	b.Set = true
+	b.BigInt, b.Valid = nil, false
+	if bytes.Equal(data, NullBytes) {
+		return nil
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+
+	var text string
+	switch x := v.(type) {
+	case json.Number:
+		text = string(x)
+	case string:
+		if len(x) == 0 {
+			return nil
+		}
+		text = x
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("nullint64: cannot unmarshal JSON %s into BigInt", jsonKind(data))
+	}
+	return b.UnmarshalText([]byte(text))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing a decimal
+// integer. Empty text, TextNullToken and TextNullWords decode as null.
+func (b *BigInt) UnmarshalText(text []byte) error {
+	b.Set = true
+	b.BigInt, b.Valid = nil, false
+	if len(text) == 0 || isNullWord(text) {
+		return nil
+	}
+	n, ok := new(big.Int).SetString(string(text), 10)
+	if !ok {
+		return fmt.Errorf("nullint64: %q is not a valid BigInt", text)
+	}
+	b.BigInt, b.Valid = n, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the value as a bare JSON
+// number of full precision.
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return appendNullJSON(nil), nil
+	}
+	return b.BigInt.Append(nil, 10), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b BigInt) MarshalText() ([]byte, error) {
+	if !b.Valid || b.BigInt == nil {
+		return []byte(TextNullToken), nil
+	}
+	return b.BigInt.Append(nil, 10), nil
+}
+
+// SetValid changes this BigInt's value and also sets it to be non-null,
+// or null if v is nil.
+func (b *BigInt) SetValid(v *big.Int) {
+	*b = BigIntFrom(v)
+}
+
+// SetNull sets this BigInt to an explicit null.
+func (b *BigInt) SetNull() {
+	*b = BigInt{Set: true}
+}
+
+// IsZero returns true for invalid BigInt's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (b BigInt) IsZero() bool {
+	return isZero(b.Valid, b.Set)
+}
+
+// Scan implements the Scanner interface. It accepts integers, decimal text
+// as returned for NUMERIC columns, and floats with no fractional part.
+func (b *BigInt) Scan(value interface{}) error {
+	if value == nil || isNilPointer(value) {
+		b.BigInt, b.Valid, b.Set = nil, false, true
+		return nil
+	}
+	b.Set = true
+	b.BigInt, b.Valid = nil, false
+	if f, ok := value.(float64); ok {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return newScanError(value, fmt.Errorf("converting driver.Value type float64 (%v) to a big.Int: %w", f, errNotFinite))
+		}
+		n, acc := big.NewFloat(f).Int(nil)
+		if acc != big.Exact {
+			return newScanError(value, fmt.Errorf("converting driver.Value type float64 (%v) to a big.Int: %w", f, ErrFractional))
+		}
+		b.BigInt, b.Valid = n, true
+		return nil
+	}
+	var text string
+	if err := convert.ConvertAssign(&text, value); err != nil {
+		return err
+	}
+	n, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		return newScanError(value, fmt.Errorf("converting driver.Value type %T (%q) to a big.Int: %w", value, text, strconv.ErrSyntax))
+	}
+	b.BigInt, b.Valid = n, true
+	return nil
+}
+
+// Value implements the driver Valuer interface, returning the value as
+// decimal text, which drivers accept for NUMERIC columns.
+func (b BigInt) Value() (driver.Value, error) {
+	if !b.Valid || b.BigInt == nil {
+		return nil, nil
+	}
+	return b.BigInt.String(), nil
+}
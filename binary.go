@@ -0,0 +1,250 @@
+package nullint64
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// Flag bits of the binary encoding.
+const (
+	flagSet   = 1 << 0
+	flagValid = 1 << 1
+)
+
+// appendBinaryFlags returns the binary encoding of a value with the given
+// state: a flags byte holding set and valid, followed by payload if valid,
+// or no bytes at all if neither flag is set.
+func appendBinaryFlags(set, valid bool, payload []byte) []byte {
+	var flags byte
+	if set {
+		flags |= flagSet
+	}
+	if valid {
+		flags |= flagValid
+	}
+	if flags == 0 {
+		return []byte{}
+	}
+	if !valid {
+		return []byte{flags}
+	}
+	return append([]byte{flags}, payload...)
+}
+
+// parseBinaryFlags splits a binary encoding into its state and payload.
+// The payload of a valid value must be size bytes long, or any length if
+// size is negative; a null must have none.
+func parseBinaryFlags(data []byte, size int) (set, valid bool, payload []byte, err error) {
+	if len(data) == 0 {
+		return false, false, nil, nil
+	}
+	flags := data[0]
+	if flags&^(flagSet|flagValid) != 0 {
+		return false, false, nil, errors.New("nullint64: invalid binary encoding flags")
+	}
+	set, valid, payload = flags&flagSet != 0, flags&flagValid != 0, data[1:]
+	if (valid && size >= 0 && len(payload) != size) || (!valid && len(payload) != 0) {
+		return false, false, nil, errors.New("nullint64: invalid binary encoding length")
+	}
+	return set, valid, payload, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a
+// flags byte holding Set and Valid, followed by the value as 8 big-endian
+// bytes when Valid. An Int64 with neither flag encodes as no bytes at all.
+func (i Int64) MarshalBinary() ([]byte, error) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(i.Int64))
+	return appendBinaryFlags(i.Set, i.Valid, b[:]), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Empty input
+// decodes as the unset zero Int64.
+func (i *Int64) UnmarshalBinary(data []byte) error {
+	set, valid, payload, err := parseBinaryFlags(data, 8)
+	if err != nil {
+		return err
+	}
+	*i = Int64{Set: set, Valid: valid}
+	if valid {
+		i.Int64 = int64(binary.BigEndian.Uint64(payload))
+	}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary encoding.
+func (i Int64) GobEncode() ([]byte, error) {
+	return i.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary encoding.
+func (i *Int64) GobDecode(data []byte) error {
+	return i.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the flags byte
+// of Int64.MarshalBinary, followed when Valid by the value as 8 big-endian
+// bytes.
+func (u Uint64) MarshalBinary() ([]byte, error) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], u.Uint64)
+	return appendBinaryFlags(u.Set, u.Valid, b[:]), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint64) UnmarshalBinary(data []byte) error {
+	set, valid, payload, err := parseBinaryFlags(data, 8)
+	if err != nil {
+		return err
+	}
+	*u = Uint64{Set: set, Valid: valid}
+	if valid {
+		u.Uint64 = binary.BigEndian.Uint64(payload)
+	}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary encoding.
+func (u Uint64) GobEncode() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary encoding.
+func (u *Uint64) GobDecode(data []byte) error {
+	return u.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the flags byte
+// of Int64.MarshalBinary, followed when Valid by the IEEE 754 bits of the
+// value as 8 big-endian bytes.
+func (f Float64) MarshalBinary() ([]byte, error) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], math.Float64bits(f.Float64))
+	return appendBinaryFlags(f.Set, f.Valid, b[:]), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (f *Float64) UnmarshalBinary(data []byte) error {
+	set, valid, payload, err := parseBinaryFlags(data, 8)
+	if err != nil {
+		return err
+	}
+	*f = Float64{Set: set, Valid: valid}
+	if valid {
+		f.Float64 = math.Float64frombits(binary.BigEndian.Uint64(payload))
+	}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary encoding.
+func (f Float64) GobEncode() ([]byte, error) {
+	return f.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary encoding.
+func (f *Float64) GobDecode(data []byte) error {
+	return f.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the flags byte
+// of Int64.MarshalBinary, followed when Valid by a byte holding 1 for true
+// or 0 for false.
+func (b Bool) MarshalBinary() ([]byte, error) {
+	var v byte
+	if b.Bool {
+		v = 1
+	}
+	return appendBinaryFlags(b.Set, b.Valid, []byte{v}), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (b *Bool) UnmarshalBinary(data []byte) error {
+	set, valid, payload, err := parseBinaryFlags(data, 1)
+	if err != nil {
+		return err
+	}
+	if valid && payload[0] > 1 {
+		return errors.New("nullint64: invalid binary encoding of a bool")
+	}
+	*b = Bool{Bool: valid && payload[0] == 1, Set: set, Valid: valid}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary encoding.
+func (b Bool) GobEncode() ([]byte, error) {
+	return b.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary encoding.
+func (b *Bool) GobDecode(data []byte) error {
+	return b.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the flags byte
+// of Int64.MarshalBinary, followed when Valid by the bytes of the string,
+// so a valid "" and a null still encode differently.
+func (s String) MarshalBinary() ([]byte, error) {
+	return appendBinaryFlags(s.Set, s.Valid, []byte(s.String)), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *String) UnmarshalBinary(data []byte) error {
+	set, valid, payload, err := parseBinaryFlags(data, -1)
+	if err != nil {
+		return err
+	}
+	*s = String{String: string(payload), Set: set, Valid: valid}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary encoding.
+func (s String) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary encoding.
+func (s *String) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the flags byte
+// of Int64.MarshalBinary, followed when Valid by the time.Time binary
+// encoding, which keeps the zone offset.
+func (t Time) MarshalBinary() ([]byte, error) {
+	if !t.Valid {
+		return appendBinaryFlags(t.Set, false, nil), nil
+	}
+	b, err := t.Time.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return appendBinaryFlags(t.Set, true, b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *Time) UnmarshalBinary(data []byte) error {
+	set, valid, payload, err := parseBinaryFlags(data, -1)
+	if err != nil {
+		return err
+	}
+	var v time.Time
+	if valid {
+		if err := v.UnmarshalBinary(payload); err != nil {
+			return err
+		}
+	}
+	*t = Time{Time: v, Set: set, Valid: valid}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the MarshalBinary encoding.
+func (t Time) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the UnmarshalBinary encoding.
+func (t *Time) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
@@ -0,0 +1,167 @@
+package nullint64
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	tests := []struct {
+		in      Int64
+		wantLen int
+	}{
+		{Int64{}, 0},
+		{NewInt64(0, false), 1},
+		{Int64From(0), 9},
+		{Int64From(math.MinInt64), 9},
+		{Int64{Int64: 4, Valid: true}, 9},
+	}
+	for _, tt := range tests {
+		b, err := tt.in.MarshalBinary()
+		if err != nil || len(b) != tt.wantLen {
+			t.Errorf("MarshalBinary(%+v) = %x, %v, want %d bytes", tt.in, b, err, tt.wantLen)
+			continue
+		}
+		back := Int64From(99)
+		if err := back.UnmarshalBinary(b); err != nil || back != tt.in {
+			t.Errorf("UnmarshalBinary(%x) = %+v, %v, want %+v", b, back, err, tt.in)
+		}
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	for _, b := range [][]byte{{0x04}, {flagSet | flagValid}, {flagSet, 0}, make([]byte, 10)} {
+		var i Int64
+		if err := i.UnmarshalBinary(b); err == nil {
+			t.Errorf("UnmarshalBinary(%x) = %+v, want error", b, i)
+		}
+	}
+}
+
+func TestGobPreservesSet(t *testing.T) {
+	type record struct {
+		A, B, C Int64
+	}
+	in := record{A: Int64From(5), B: NewInt64(0, false)}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if out != in {
+		t.Errorf("gob round trip = %+v, want %+v", out, in)
+	}
+}
+
+// sameValue reports whether a and b, both of one of the nullable types,
+// hold the same state, comparing times by instant.
+func sameValue(a, b interface{}) bool {
+	if ta, ok := a.(Time); ok {
+		tb, ok := b.(Time)
+		return ok && ta.Valid == tb.Valid && ta.Set == tb.Set && ta.Time.Equal(tb.Time)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// siblingValues are valid, null and unset values of each type nullgen can
+// wrap, other than Int64.
+var siblingValues = []interface{}{
+	Uint64From(math.MaxUint64), NewUint64(0, false), Uint64{},
+	Float64From(-1.5), NewFloat64(0, false), Float64{},
+	BoolFrom(true), BoolFrom(false), NewBool(false, false), Bool{},
+	StringFrom("héllo"), StringFrom(""), NewString("", false), String{},
+	TimeFrom(time.Date(2024, 2, 29, 12, 30, 0, 5, time.UTC)), NewTime(time.Time{}, false), Time{},
+	Int32From(math.MinInt32), NewInt32(0, false), Int32{},
+	Int16From(math.MaxInt16), Int16From(-1), NewInt16(0, false),
+	Int8From(math.MinInt8), Int8From(7), NewInt8(0, false),
+	Uint32From(math.MaxUint32), NewUint32(0, false), Uint32{},
+}
+
+// newOf returns a pointer to a new zero value of the type of v.
+func newOf(v interface{}) interface{} {
+	return reflect.New(reflect.TypeOf(v)).Interface()
+}
+
+// elem returns the value p points to.
+func elem(p interface{}) interface{} {
+	return reflect.ValueOf(p).Elem().Interface()
+}
+
+func TestSiblingBinaryRoundTrip(t *testing.T) {
+	for _, v := range siblingValues {
+		b, err := v.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Errorf("%T.MarshalBinary(%+v): %v", v, v, err)
+			continue
+		}
+		p := newOf(v)
+		if err := p.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil || !sameValue(elem(p), v) {
+			t.Errorf("%T.UnmarshalBinary(%x) = %+v, %v, want %+v", v, b, elem(p), err, v)
+		}
+	}
+
+	// Distinct states must not collide, notably a valid "" and a null.
+	e, _ := StringFrom("").MarshalBinary()
+	n, _ := NewString("", false).MarshalBinary()
+	if bytes.Equal(e, n) {
+		t.Errorf("valid \"\" and null both encode as %x", e)
+	}
+}
+
+func TestSiblingUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		p    encoding.BinaryUnmarshaler
+		data []byte
+	}{
+		{new(Uint64), []byte{flagSet | flagValid, 1}},
+		{new(Float64), []byte{0x04}},
+		{new(Bool), []byte{flagSet | flagValid, 2}},
+		{new(String), []byte{flagSet, 'x'}},
+		{new(Time), []byte{flagSet | flagValid, 0xff}},
+		{new(Int8), append([]byte{flagSet | flagValid}, 0, 0, 0, 0, 0, 0, 1, 0)},
+		{new(Uint32), append([]byte{flagSet | flagValid}, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff)},
+	}
+	for _, tt := range tests {
+		if err := tt.p.UnmarshalBinary(tt.data); err == nil {
+			t.Errorf("%T.UnmarshalBinary(%x) = %+v, want error", tt.p, tt.data, tt.p)
+		}
+	}
+}
+
+func TestSiblingGob(t *testing.T) {
+	type record struct {
+		U Uint64
+		F Float64
+		B Bool
+		S String
+		T Time
+		I Int16
+	}
+	in := record{
+		U: Uint64From(1), F: NewFloat64(0, false), B: BoolFrom(false),
+		S: StringFrom(""), T: TimeFrom(time.Unix(1e9, 0).UTC()),
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !out.T.Time.Equal(in.T.Time) {
+		t.Errorf("gob round trip of T = %v, want %v", out.T, in.T)
+	}
+	out.T.Time = in.T.Time
+	if out != in {
+		t.Errorf("gob round trip = %+v, want %+v", out, in)
+	}
+}
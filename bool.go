@@ -0,0 +1,168 @@
+package nullint64
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ccakes/nullint64/internal/convert"
+)
+
+// Bool is a nullable bool with the same Valid/Set semantics as Int64.
+type Bool struct {
+	Bool  bool
+	Valid bool
+	Set   bool
+}
+
+// NewBool creates a new Bool
+func NewBool(b bool, valid bool) Bool {
+	return Bool{
+		Bool:  b,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// BoolFrom creates a new Bool that will always be valid.
+func BoolFrom(b bool) Bool {
+	return NewBool(b, true)
+}
+
+// BoolFromPtr creates a new Bool that will be null if b is nil.
+func BoolFromPtr(b *bool) Bool {
+	if b == nil {
+		return NewBool(false, false)
+	}
+	return NewBool(*b, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (b Bool) IsValid() bool {
+	return b.Set && b.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (b Bool) IsSet() bool {
+	return b.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler. As for Int64, an empty JSON
+// string decodes as null.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	b.Set = true
+	if bytes.Equal(data, NullBytes) || string(data) == `""` {
+		b.Bool, b.Valid = false, false
+		return nil
+	}
+	if jsonKind(data) != "bool" {
+		if !json.Valid(data) {
+			return json.Unmarshal(data, new(interface{}))
+		}
+		b.Bool, b.Valid = false, false
+		return fmt.Errorf("nullint64: cannot unmarshal JSON %s into Bool", jsonKind(data))
+	}
+	err := json.Unmarshal(data, &b.Bool)
+	b.Valid = err == nil
+	if !b.Valid {
+		b.Bool = false
+	}
+	return err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the forms
+// strconv.ParseBool does.
+func (b *Bool) UnmarshalText(text []byte) error {
+	b.Set = true
+	if len(text) == 0 || isNullWord(text) {
+		b.Bool, b.Valid = false, false
+		return nil
+	}
+	var err error
+	b.Bool, err = strconv.ParseBool(string(text))
+	b.Valid = err == nil
+	if !b.Valid {
+		b.Bool = false
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler. A null is always encoded as
+// JSON null, whatever MarshalNullAsZero or MarshalNullAsEmptyString say.
+func (b Bool) MarshalJSON() ([]byte, error) {
+	if !b.Valid {
+		return append([]byte(nil), NullBytes...), nil
+	}
+	return strconv.AppendBool(nil, b.Bool), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b Bool) MarshalText() ([]byte, error) {
+	if !b.Valid {
+		return []byte(TextNullToken), nil
+	}
+	return strconv.AppendBool(nil, b.Bool), nil
+}
+
+// AppendText implements encoding.TextAppender, appending the MarshalText
+// form of the value to buf.
+func (b Bool) AppendText(buf []byte) ([]byte, error) {
+	if !b.Valid {
+		return append(buf, TextNullToken...), nil
+	}
+	return strconv.AppendBool(buf, b.Bool), nil
+}
+
+// SetValid changes this Bool's value and also sets it to be non-null.
+func (b *Bool) SetValid(v bool) {
+	b.Bool = v
+	b.Valid = true
+	b.Set = true
+}
+
+// SetNull sets this Bool to an explicit null.
+func (b *Bool) SetNull() {
+	b.Bool = false
+	b.Valid = false
+	b.Set = true
+}
+
+// Ptr returns a pointer to this Bool's value, or a nil pointer if this Bool is null.
+func (b Bool) Ptr() *bool {
+	if !b.Valid {
+		return nil
+	}
+	return &b.Bool
+}
+
+// IsZero returns true for invalid Bool's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (b Bool) IsZero() bool {
+	return isZero(b.Valid, b.Set)
+}
+
+// Scan implements the Scanner interface.
+func (b *Bool) Scan(value interface{}) error {
+	if value == nil || isNilPointer(value) {
+		b.Bool, b.Valid, b.Set = false, false, true
+		return nil
+	}
+	b.Set = true
+	if err := convert.ConvertAssign(&b.Bool, value); err != nil {
+		b.Bool, b.Valid = false, false
+		return err
+	}
+	b.Valid = true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (b Bool) Value() (driver.Value, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.Bool, nil
+}
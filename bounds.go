@@ -0,0 +1,93 @@
+package nullint64
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// ErrOutOfRange matches, through errors.Is, the *RangeError returned by the
+// range-checked functions in this file.
+var ErrOutOfRange = errors.New("nullint64: value out of range")
+
+// RangeError reports a value outside the range [Min, Max].
+type RangeError struct {
+	Value    int64
+	Min, Max int64
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("nullint64: %d is outside the range [%d, %d]", e.Value, e.Min, e.Max)
+}
+
+// Is reports whether target is ErrOutOfRange.
+func (e *RangeError) Is(target error) bool {
+	return target == ErrOutOfRange
+}
+
+// checkRange returns a *RangeError if v is outside [min, max].
+func checkRange(v, min, max int64) error {
+	if v < min || v > max {
+		return &RangeError{Value: v, Min: min, Max: max}
+	}
+	return nil
+}
+
+// NewBounded creates a new valid Int64 holding v, or returns a *RangeError
+// if v is outside [min, max].
+func NewBounded(v int64, min, max int64) (Int64, error) {
+	if err := checkRange(v, min, max); err != nil {
+		return Int64{}, err
+	}
+	return Int64From(v), nil
+}
+
+// SetValidChecked is like SetValid but returns a *RangeError, leaving this
+// Int64 unchanged, if v is outside [min, max].
+func (i *Int64) SetValidChecked(v, min, max int64) error {
+	if err := checkRange(v, min, max); err != nil {
+		return err
+	}
+	i.SetValid(v)
+	return nil
+}
+
+// ValueBounded is like Value but returns a *RangeError if this Int64 is valid
+// and outside [min, max], catching values too wide for a narrower column
+// type, such as a 32-bit INT, before the database rejects them. Null
+// returns nil.
+func (i Int64) ValueBounded(min, max int64) (driver.Value, error) {
+	if i.Valid {
+		if err := checkRange(i.Int64, min, max); err != nil {
+			return nil, err
+		}
+	}
+	return i.Value()
+}
+
+// MustBounded is like NewBounded but panics if v is out of range. It is
+// intended for literals known to be in range.
+func MustBounded(v int64, min, max int64) Int64 {
+	i, err := NewBounded(v, min, max)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+// InRangeEx reports whether this Int64 is valid and lies between min and
+// max, with incMin and incMax choosing whether each bound is inclusive. For
+// example InRangeEx(0, 100, false, true) checks 0 < i <= 100.
+func (i Int64) InRangeEx(min, max int64, incMin, incMax bool) bool {
+	if !i.Valid {
+		return false
+	}
+	v := i.Int64
+	if v < min || (v == min && !incMin) {
+		return false
+	}
+	if v > max || (v == max && !incMax) {
+		return false
+	}
+	return true
+}
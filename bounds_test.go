@@ -0,0 +1,93 @@
+package nullint64
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewBounded(t *testing.T) {
+	tests := []struct {
+		v, min, max int64
+		wantErr     bool
+	}{
+		{5, 0, 10, false},
+		{0, 0, 10, false},
+		{10, 0, 10, false},
+		{-1, 0, 10, true},
+		{11, 0, 10, true},
+	}
+	for _, tt := range tests {
+		got, err := NewBounded(tt.v, tt.min, tt.max)
+		if tt.wantErr {
+			var re *RangeError
+			if !errors.As(err, &re) || re.Value != tt.v || re.Min != tt.min || re.Max != tt.max {
+				t.Errorf("NewBounded(%d, %d, %d) error = %v, want *RangeError", tt.v, tt.min, tt.max, err)
+			}
+			if got != (Int64{}) {
+				t.Errorf("NewBounded(%d, %d, %d) = %+v on error", tt.v, tt.min, tt.max, got)
+			}
+			continue
+		}
+		if err != nil || got != Int64From(tt.v) {
+			t.Errorf("NewBounded(%d, %d, %d) = %+v, %v", tt.v, tt.min, tt.max, got, err)
+		}
+	}
+}
+
+func TestMustBounded(t *testing.T) {
+	if got := MustBounded(3, 1, 5); got != Int64From(3) {
+		t.Errorf("MustBounded(3, 1, 5) = %+v", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustBounded(6, 1, 5) didn't panic")
+		}
+	}()
+	MustBounded(6, 1, 5)
+}
+
+func TestInRangeEx(t *testing.T) {
+	tests := []struct {
+		in             Int64
+		incMin, incMax bool
+		want           bool
+	}{
+		{Int64From(0), true, true, true},
+		{Int64From(0), false, true, false},
+		{Int64From(100), true, true, true},
+		{Int64From(100), true, false, false},
+		{Int64From(50), false, false, true},
+		{Int64From(-1), true, true, false},
+		{Int64From(101), true, true, false},
+		{NewInt64(0, false), true, true, false},
+	}
+	for _, tt := range tests {
+		if got := tt.in.InRangeEx(0, 100, tt.incMin, tt.incMax); got != tt.want {
+			t.Errorf("InRangeEx(%+v, 0, 100, %v, %v) = %v, want %v", tt.in, tt.incMin, tt.incMax, got, tt.want)
+		}
+	}
+}
+
+func TestValueBounded(t *testing.T) {
+	tests := []struct {
+		in      Int64
+		want    interface{}
+		wantErr bool
+	}{
+		{Int64From(math.MaxInt32), int64(math.MaxInt32), false},
+		{Int64From(math.MaxInt32 + 1), nil, true},
+		{Int64From(math.MinInt32 - 1), nil, true},
+		{NewInt64(0, false), nil, false},
+		{Int64{}, nil, false},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.ValueBounded(math.MinInt32, math.MaxInt32)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ValueBounded(%+v) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+		if tt.wantErr && !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("ValueBounded(%+v) error %v doesn't match ErrOutOfRange", tt.in, err)
+		}
+	}
+}
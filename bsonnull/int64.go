@@ -0,0 +1,67 @@
+// Package bsonnull adapts nullint64.Int64 to the BSON codec of the MongoDB
+// Go driver, so values are stored as BSON int64 or null.
+//
+// A field that is absent from a document is never decoded, leaving Set
+// false, while a BSON null decodes with Set true and Valid false. When
+// encoding, a null is written as BSON null; the omitempty struct tag omits
+// nulls as well as unset values, since IsZero reports both, unless
+// nullint64.OmitUnsetOnly is set.
+package bsonnull
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ccakes/nullint64"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Int64 is a nullint64.Int64 implementing bson.ValueMarshaler and
+// bson.ValueUnmarshaler.
+type Int64 struct {
+	nullint64.Int64
+}
+
+// MarshalBSONValue implements bson.ValueMarshaler.
+func (i Int64) MarshalBSONValue() (byte, []byte, error) {
+	if !i.Valid {
+		return byte(bson.TypeNull), nil, nil
+	}
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(i.Int64.Int64))
+	return byte(bson.TypeInt64), b, nil
+}
+
+// UnmarshalBSONValue implements bson.ValueUnmarshaler. It accepts BSON
+// int64, int32 and doubles with no fractional part, and decodes null and
+// undefined as null.
+func (i *Int64) UnmarshalBSONValue(typ byte, data []byte) error {
+	rv := bson.RawValue{Type: bson.Type(typ), Value: data}
+	switch rv.Type {
+	case bson.TypeNull, bson.TypeUndefined:
+		i.SetNull()
+		return nil
+	case bson.TypeInt64:
+		if n, ok := rv.Int64OK(); ok {
+			i.SetValid(n)
+			return nil
+		}
+	case bson.TypeInt32:
+		if n, ok := rv.Int32OK(); ok {
+			i.SetValid(int64(n))
+			return nil
+		}
+	case bson.TypeDouble:
+		if f, ok := rv.DoubleOK(); ok {
+			return i.Int64.Scan(f)
+		}
+	default:
+		return fmt.Errorf("bsonnull: cannot decode BSON %s into Int64", rv.Type)
+	}
+	return fmt.Errorf("bsonnull: malformed BSON %s", rv.Type)
+}
+
+var (
+	_ bson.ValueMarshaler   = Int64{}
+	_ bson.ValueUnmarshaler = (*Int64)(nil)
+)
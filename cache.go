@@ -0,0 +1,94 @@
+package nullint64
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+)
+
+// cacheV1 is the header of version 1 of the cache encoding. Its low bits
+// hold the flagSet and flagValid bits of the binary encoding, so headers
+// of the two never collide.
+const cacheV1 = 0x10
+
+// EncodeCache encodes i compactly for a cache such as Redis or memcached.
+// The encoding is versioned: version 1 is a header byte, 0x10 combined
+// with 0x01 if Set and 0x02 if Valid, followed when Valid by the value as
+// a zigzag varint as written by binary.PutVarint, so small values take
+// two bytes in total. Every state, unset included, encodes to at least
+// one byte.
+func EncodeCache(i Int64) []byte {
+	var flags byte
+	if i.Set {
+		flags |= flagSet
+	}
+	if !i.Valid {
+		return []byte{cacheV1 | flags}
+	}
+	b := make([]byte, 1+binary.MaxVarintLen64)
+	b[0] = cacheV1 | flags | flagValid
+	n := binary.PutVarint(b[1:], i.Int64)
+	return b[:1+n]
+}
+
+// DecodeCache decodes an Int64 written by EncodeCache. For entries
+// written by older code it also accepts the MarshalBinary encoding and
+// the two-state MarshalText form, a decimal number; since the text form
+// can't record Set, a number decodes as set. Empty input decodes as the
+// unset zero Int64, as MarshalBinary writes it, so a null entry written in
+// the text form reads back as unset.
+func DecodeCache(data []byte) (Int64, error) {
+	if len(data) == 0 {
+		return Int64{}, nil
+	}
+
+	var i Int64
+	switch head := data[0]; {
+	case head&^(flagSet|flagValid) == cacheV1:
+		i.Set = head&flagSet != 0
+		if head&flagValid == 0 {
+			if len(data) != 1 {
+				return Int64{}, errors.New("nullint64: invalid cache encoding length")
+			}
+			return i, nil
+		}
+		n, size := binary.Varint(data[1:])
+		if size <= 0 || 1+size != len(data) {
+			return Int64{}, errors.New("nullint64: invalid cache encoding varint")
+		}
+		i.Int64, i.Valid = n, true
+		return i, nil
+	case head&^(flagSet|flagValid) == 0:
+		err := i.UnmarshalBinary(data)
+		return i, err
+	default:
+		n, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return Int64{}, errors.New("nullint64: unrecognized cache encoding")
+		}
+		return Int64From(n), nil
+	}
+}
+
+// CachedInt64 is an Int64 whose MarshalBinary and UnmarshalBinary use the
+// cache encoding of EncodeCache and DecodeCache, for clients such as
+// go-redis that store values through encoding.BinaryMarshaler. Gob
+// encoding is unchanged.
+type CachedInt64 struct {
+	Int64
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (c CachedInt64) MarshalBinary() ([]byte, error) {
+	return EncodeCache(c.Int64), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (c *CachedInt64) UnmarshalBinary(data []byte) error {
+	i, err := DecodeCache(data)
+	if err != nil {
+		return err
+	}
+	c.Int64 = i
+	return nil
+}
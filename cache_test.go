@@ -0,0 +1,32 @@
+package nullint64
+
+import (
+	"testing"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	for _, in := range []Int64{{}, NewInt64(0, false), Int64From(0), Int64From(-1), Int64From(1 << 40)} {
+		got, err := DecodeCache(EncodeCache(in))
+		if err != nil || got != in {
+			t.Errorf("DecodeCache(EncodeCache(%+v)) = %+v, %v", in, got, err)
+		}
+	}
+}
+
+func TestDecodeCacheLegacy(t *testing.T) {
+	for _, in := range []Int64{{}, NewInt64(0, false), Int64From(0), Int64From(-42)} {
+		b, _ := in.MarshalBinary()
+		got, err := DecodeCache(b)
+		if err != nil || got != in {
+			t.Errorf("DecodeCache(MarshalBinary(%+v)) = %+v, %v", in, got, err)
+		}
+	}
+
+	got, err := DecodeCache([]byte("1234"))
+	if err != nil || got != Int64From(1234) {
+		t.Errorf("DecodeCache(%q) = %+v, %v", "1234", got, err)
+	}
+	if _, err := DecodeCache([]byte("{x}")); err == nil {
+		t.Errorf("DecodeCache(%q) succeeded", "{x}")
+	}
+}
@@ -0,0 +1,393 @@
+package nullint64
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+// CBOR simple values and major types used by MarshalCBOR and
+// UnmarshalCBOR.
+const (
+	cborFalse     = 0xf4
+	cborTrue      = 0xf5
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+	cborFloat16   = 0xf9
+	cborFloat32   = 0xfa
+	cborFloat64   = 0xfb
+
+	cborUnsigned = 0 << 5
+	cborNegative = 1 << 5
+	cborText     = 3 << 5
+	cborTag      = 6 << 5
+
+	// Tags for RFC 3339 text and epoch-based date/time values.
+	cborTagDateTime = 0
+	cborTagEpoch    = 1
+)
+
+// appendCBORHead appends the head of a CBOR data item of the given major
+// type and argument, using the shortest form that holds u.
+func appendCBORHead(b []byte, major byte, u uint64) []byte {
+	switch {
+	case u < 24:
+		return append(b, major|byte(u))
+	case u <= math.MaxUint8:
+		return append(b, major|24, byte(u))
+	case u <= math.MaxUint16:
+		return append(b, major|25, byte(u>>8), byte(u))
+	case u <= math.MaxUint32:
+		return append(b, major|26, byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+	default:
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], u)
+		return append(append(b, major|27), buf[:]...)
+	}
+}
+
+// parseCBORHead splits off the head of the CBOR data item in data,
+// returning its major type and argument and the bytes that follow it.
+// Indefinite lengths are not supported.
+func parseCBORHead(data []byte) (major byte, u uint64, rest []byte, err error) {
+	if len(data) == 0 {
+		return 0, 0, nil, errors.New("nullint64: empty CBOR value")
+	}
+	major, info, body := data[0]&^0x1f, data[0]&0x1f, data[1:]
+	size := 0
+	switch {
+	case info < 24:
+		return major, uint64(info), body, nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, nil, errors.New("nullint64: invalid CBOR integer encoding")
+	}
+	if len(body) < size {
+		return 0, 0, nil, errors.New("nullint64: invalid CBOR integer encoding")
+	}
+	switch size {
+	case 1:
+		u = uint64(body[0])
+	case 2:
+		u = uint64(binary.BigEndian.Uint16(body))
+	case 4:
+		u = uint64(binary.BigEndian.Uint32(body))
+	default:
+		u = binary.BigEndian.Uint64(body)
+	}
+	return major, u, body[size:], nil
+}
+
+// isCBORNull reports whether data is a CBOR null or undefined, failing if
+// anything follows it.
+func isCBORNull(data []byte) (bool, error) {
+	if len(data) == 0 {
+		return false, errors.New("nullint64: empty CBOR value")
+	}
+	if data[0] != cborNull && data[0] != cborUndefined {
+		return false, nil
+	}
+	if len(data) != 1 {
+		return false, errors.New("nullint64: invalid CBOR length")
+	}
+	return true, nil
+}
+
+// parseCBORInt decodes the CBOR integer in data, which must hold nothing
+// else, as the signed value n or, for unsigned values too large for it,
+// as big with ok false.
+func parseCBORInt(data []byte, typ string) (n int64, big uint64, ok bool, err error) {
+	major, u, rest, err := parseCBORHead(data)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if major != cborUnsigned && major != cborNegative {
+		return 0, 0, false, fmt.Errorf("nullint64: cannot unmarshal CBOR major type %d into %s", data[0]>>5, typ)
+	}
+	if len(rest) != 0 {
+		return 0, 0, false, errors.New("nullint64: invalid CBOR integer encoding")
+	}
+	if u > math.MaxInt64 {
+		if major == cborNegative {
+			return 0, 0, false, fmt.Errorf("nullint64: CBOR integer overflows %s", typ)
+		}
+		return 0, u, false, nil
+	}
+	n = int64(u)
+	if major == cborNegative {
+		n = -1 - n
+	}
+	return n, 0, true, nil
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface of fxamacker/cbor,
+// encoding null as CBOR null and a valid value as the shortest CBOR
+// integer that holds it. It needs no import of the cbor package.
+func (i Int64) MarshalCBOR() ([]byte, error) {
+	if !i.Valid {
+		return []byte{cborNull}, nil
+	}
+	if i.Int64 < 0 {
+		// CBOR negative integers hold -1-n, which can't overflow.
+		return appendCBORHead(nil, cborNegative, uint64(-1-i.Int64)), nil
+	}
+	return appendCBORHead(nil, cborUnsigned, uint64(i.Int64)), nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface of
+// fxamacker/cbor. It accepts null and undefined, both as null, and CBOR
+// integers of any width; integers outside the range of int64 are an error.
+func (i *Int64) UnmarshalCBOR(data []byte) error {
+	i.Set = true
+	i.Int64, i.Valid = 0, false
+	if null, err := isCBORNull(data); null || err != nil {
+		return err
+	}
+	n, _, ok, err := parseCBORInt(data, "Int64")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("nullint64: CBOR integer overflows Int64")
+	}
+	i.Int64, i.Valid = n, true
+	return nil
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface, encoding null as
+// CBOR null and a valid value as the shortest CBOR unsigned integer that
+// holds it.
+func (u Uint64) MarshalCBOR() ([]byte, error) {
+	if !u.Valid {
+		return []byte{cborNull}, nil
+	}
+	return appendCBORHead(nil, cborUnsigned, u.Uint64), nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface. It accepts null
+// and undefined, both as null, and non-negative CBOR integers of any
+// width.
+func (u *Uint64) UnmarshalCBOR(data []byte) error {
+	u.Set = true
+	u.Uint64, u.Valid = 0, false
+	if null, err := isCBORNull(data); null || err != nil {
+		return err
+	}
+	n, big, ok, err := parseCBORInt(data, "Uint64")
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		u.Uint64 = big
+	case n < 0:
+		return errors.New("nullint64: CBOR integer overflows Uint64")
+	default:
+		u.Uint64 = uint64(n)
+	}
+	u.Valid = true
+	return nil
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface, encoding null as
+// CBOR null and a valid value as a double-precision CBOR float.
+func (f Float64) MarshalCBOR() ([]byte, error) {
+	if !f.Valid {
+		return []byte{cborNull}, nil
+	}
+	b := make([]byte, 9)
+	b[0] = cborFloat64
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(f.Float64))
+	return b, nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface. It accepts null
+// and undefined, both as null, CBOR floats of every precision and CBOR
+// integers.
+func (f *Float64) UnmarshalCBOR(data []byte) error {
+	f.Set = true
+	f.Float64, f.Valid = 0, false
+	if null, err := isCBORNull(data); null || err != nil {
+		return err
+	}
+	switch head, body := data[0], data[1:]; {
+	case head == cborFloat16 && len(body) == 2:
+		f.Float64 = float16to64(binary.BigEndian.Uint16(body))
+	case head == cborFloat32 && len(body) == 4:
+		f.Float64 = float64(math.Float32frombits(binary.BigEndian.Uint32(body)))
+	case head == cborFloat64 && len(body) == 8:
+		f.Float64 = math.Float64frombits(binary.BigEndian.Uint64(body))
+	case head == cborFloat16 || head == cborFloat32 || head == cborFloat64:
+		return errors.New("nullint64: invalid CBOR float encoding")
+	default:
+		n, big, ok, err := parseCBORInt(data, "Float64")
+		if err != nil {
+			return err
+		}
+		f.Float64 = float64(n)
+		if !ok {
+			f.Float64 = float64(big)
+		}
+	}
+	f.Valid = true
+	return nil
+}
+
+// float16to64 converts the IEEE 754 half-precision value h.
+func float16to64(h uint16) float64 {
+	exp, frac := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(frac, -24)
+	case 0x1f:
+		f = math.Inf(1)
+		if frac != 0 {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(frac+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface, encoding null as
+// CBOR null and a valid value as CBOR true or false.
+func (b Bool) MarshalCBOR() ([]byte, error) {
+	switch {
+	case !b.Valid:
+		return []byte{cborNull}, nil
+	case b.Bool:
+		return []byte{cborTrue}, nil
+	default:
+		return []byte{cborFalse}, nil
+	}
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface. It accepts null
+// and undefined, both as null, and CBOR true and false.
+func (b *Bool) UnmarshalCBOR(data []byte) error {
+	b.Set = true
+	b.Bool, b.Valid = false, false
+	if null, err := isCBORNull(data); null || err != nil {
+		return err
+	}
+	if len(data) != 1 || (data[0] != cborTrue && data[0] != cborFalse) {
+		return errors.New("nullint64: cannot unmarshal CBOR value into Bool")
+	}
+	b.Bool, b.Valid = data[0] == cborTrue, true
+	return nil
+}
+
+// appendCBORText appends s as a definite-length CBOR text string.
+func appendCBORText(b []byte, s string) []byte {
+	return append(appendCBORHead(b, cborText, uint64(len(s))), s...)
+}
+
+// parseCBORText decodes the definite-length CBOR text string that data
+// holds and nothing else.
+func parseCBORText(data []byte, typ string) (string, error) {
+	major, n, rest, err := parseCBORHead(data)
+	if err != nil {
+		return "", err
+	}
+	if major != cborText {
+		return "", fmt.Errorf("nullint64: cannot unmarshal CBOR major type %d into %s", data[0]>>5, typ)
+	}
+	if uint64(len(rest)) != n {
+		return "", errors.New("nullint64: invalid CBOR length")
+	}
+	if !utf8.Valid(rest) {
+		return "", errors.New("nullint64: invalid UTF-8 in CBOR text string")
+	}
+	return string(rest), nil
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface, encoding null as
+// CBOR null and a valid value as a CBOR text string.
+func (s String) MarshalCBOR() ([]byte, error) {
+	if !s.Valid {
+		return []byte{cborNull}, nil
+	}
+	return appendCBORText(nil, s.String), nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface. It accepts null
+// and undefined, both as null, and definite-length CBOR text strings.
+func (s *String) UnmarshalCBOR(data []byte) error {
+	s.Set = true
+	s.String, s.Valid = "", false
+	if null, err := isCBORNull(data); null || err != nil {
+		return err
+	}
+	v, err := parseCBORText(data, "String")
+	if err != nil {
+		return err
+	}
+	s.String, s.Valid = v, true
+	return nil
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface, encoding null as
+// CBOR null and a valid value as an RFC 3339 string with the standard
+// date/time tag 0.
+func (t Time) MarshalCBOR() ([]byte, error) {
+	if !t.Valid {
+		return []byte{cborNull}, nil
+	}
+	text, err := t.Time.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return appendCBORText([]byte{cborTag | cborTagDateTime}, string(text)), nil
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface. It accepts null
+// and undefined, both as null, tag 0 RFC 3339 strings and tag 1 epoch
+// times in seconds, integer or float.
+func (t *Time) UnmarshalCBOR(data []byte) error {
+	t.Set = true
+	t.Time, t.Valid = time.Time{}, false
+	if null, err := isCBORNull(data); null || err != nil {
+		return err
+	}
+	major, tag, rest, err := parseCBORHead(data)
+	if err != nil {
+		return err
+	}
+	if major != cborTag || (tag != cborTagDateTime && tag != cborTagEpoch) {
+		return errors.New("nullint64: cannot unmarshal CBOR value into Time")
+	}
+
+	var v time.Time
+	if tag == cborTagDateTime {
+		text, err := parseCBORText(rest, "Time")
+		if err != nil {
+			return err
+		}
+		if err := v.UnmarshalText([]byte(text)); err != nil {
+			return err
+		}
+	} else {
+		var secs Float64
+		if err := secs.UnmarshalCBOR(rest); err != nil || !secs.Valid {
+			return errors.New("nullint64: invalid CBOR epoch time")
+		}
+		whole, frac := math.Modf(secs.Float64)
+		v = time.Unix(int64(whole), int64(frac*1e9)).UTC()
+	}
+	t.Time, t.Valid = v, true
+	return nil
+}
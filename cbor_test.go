@@ -0,0 +1,138 @@
+package nullint64
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+type cborCodec interface {
+	MarshalCBOR() ([]byte, error)
+}
+
+type cborDecoder interface {
+	UnmarshalCBOR(data []byte) error
+}
+
+func TestMarshalCBOR(t *testing.T) {
+	tests := []struct {
+		in   cborCodec
+		want []byte
+	}{
+		{Int64From(0), []byte{0x00}},
+		{Int64From(23), []byte{0x17}},
+		{Int64From(24), []byte{0x18, 0x18}},
+		{Int64From(-1), []byte{0x20}},
+		{Int64From(-1000), []byte{0x39, 0x03, 0xe7}},
+		{Int64From(math.MaxInt64), []byte{0x1b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{NewInt64(0, false), []byte{0xf6}},
+		{Uint64From(math.MaxUint64), []byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{Float64From(1.5), []byte{0xfb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{BoolFrom(true), []byte{0xf5}},
+		{BoolFrom(false), []byte{0xf4}},
+		{StringFrom("IETF"), []byte{0x64, 'I', 'E', 'T', 'F'}},
+		{StringFrom(""), []byte{0x60}},
+		{NewString("", false), []byte{0xf6}},
+		{TimeFrom(time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)), append([]byte{0xc0, 0x74}, "2013-03-21T20:04:00Z"...)},
+		{Int16From(-1), []byte{0x20}},
+		{Uint32From(math.MaxUint32), []byte{0x1a, 0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.MarshalCBOR()
+		if err != nil || !bytes.Equal(got, tt.want) {
+			t.Errorf("%T.MarshalCBOR(%+v) = %x, %v, want %x", tt.in, tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	values := append([]interface{}{Int64From(math.MinInt64), Int64From(300), NewInt64(0, false)}, siblingValues...)
+	for _, v := range values {
+		if !v.(interface{ IsSet() bool }).IsSet() {
+			// CBOR has no unset state; unset encodes as null.
+			continue
+		}
+		b, err := v.(cborCodec).MarshalCBOR()
+		if err != nil {
+			t.Errorf("%T.MarshalCBOR(%+v): %v", v, v, err)
+			continue
+		}
+		p := newOf(v)
+		if err := p.(cborDecoder).UnmarshalCBOR(b); err != nil || !sameValue(elem(p), v) {
+			t.Errorf("%T.UnmarshalCBOR(%x) = %+v, %v, want %+v", v, b, elem(p), err, v)
+		}
+	}
+}
+
+func TestUnmarshalCBOR(t *testing.T) {
+	var f Float64
+	for _, tt := range []struct {
+		data []byte
+		want float64
+	}{
+		{[]byte{0xf9, 0x3c, 0x00}, 1},
+		{[]byte{0xf9, 0xc4, 0x00}, -4},
+		{[]byte{0xf9, 0x00, 0x01}, 5.960464477539063e-8},
+		{[]byte{0xfa, 0x47, 0xc3, 0x50, 0x00}, 100000},
+		{[]byte{0x19, 0x03, 0xe8}, 1000},
+		{[]byte{0x38, 0x63}, -100},
+	} {
+		if err := f.UnmarshalCBOR(tt.data); err != nil || f != Float64From(tt.want) {
+			t.Errorf("Float64.UnmarshalCBOR(%x) = %+v, %v, want %v", tt.data, f, err, tt.want)
+		}
+	}
+
+	var tm Time
+	if err := tm.UnmarshalCBOR([]byte{0xc1, 0x1a, 0x51, 0x4b, 0x67, 0xb0}); err != nil || !tm.Time.Equal(time.Unix(1363896240, 0)) {
+		t.Errorf("Time.UnmarshalCBOR(epoch) = %+v, %v", tm, err)
+	}
+	if err := tm.UnmarshalCBOR([]byte{0xc1, 0xfb, 0x41, 0xd4, 0x52, 0xd9, 0xec, 0x20, 0x00, 0x00}); err != nil || !tm.Time.Equal(time.Unix(1363896240, 5e8)) {
+		t.Errorf("Time.UnmarshalCBOR(float epoch) = %+v, %v", tm, err)
+	}
+
+	for _, p := range []cborDecoder{new(Int64), new(Uint64), new(Float64), new(Bool), new(String), new(Time), new(Int8)} {
+		if err := p.UnmarshalCBOR([]byte{0xf7}); err != nil || !sameValue(elem(p), reflectNull(p)) {
+			t.Errorf("%T.UnmarshalCBOR(undefined) = %+v, %v, want null", p, p, err)
+		}
+	}
+}
+
+// reflectNull returns an explicit null of the type p points to.
+func reflectNull(p interface{}) interface{} {
+	q := newOf(elem(p))
+	q.(interface{ SetNull() }).SetNull()
+	return elem(q)
+}
+
+func TestUnmarshalCBORErrors(t *testing.T) {
+	tests := []struct {
+		p    cborDecoder
+		data []byte
+	}{
+		{new(Int64), nil},
+		{new(Int64), []byte{0xf6, 0x00}},
+		{new(Int64), []byte{0x18}},
+		{new(Int64), []byte{0x1b, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+		{new(Int64), []byte{0x60}},
+		{new(Uint64), []byte{0x20}},
+		{new(Float64), []byte{0xfb, 0x00}},
+		{new(Float64), []byte{0xf5}},
+		{new(Bool), []byte{0x01}},
+		{new(String), []byte{0x62, 'a'}},
+		{new(String), []byte{0x61, 0xff}},
+		{new(String), []byte{0x41, 'a'}},
+		{new(Time), []byte{0x74, '2'}},
+		{new(Time), []byte{0xc0, 0x63, 'x', 'y', 'z'}},
+		{new(Int8), []byte{0x18, 0xff}},
+		{new(Uint32), []byte{0x20}},
+	}
+	for _, tt := range tests {
+		if err := tt.p.UnmarshalCBOR(tt.data); err == nil {
+			t.Errorf("%T.UnmarshalCBOR(%x) = %+v, want error", tt.p, tt.data, tt.p)
+		}
+		if v := tt.p.(interface{ IsValid() bool }); v.IsValid() {
+			t.Errorf("%T.UnmarshalCBOR(%x) left a valid value", tt.p, tt.data)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Command nullgen generates a nullable wrapper for a named type, with the
+// Valid/Set semantics of nullint64.Int64, so distinct ID and quantity types
+// keep their type safety when nullable. For example
+//
+//	//go:generate nullgen -type OrderID -base int64
+//
+// writes orderid_null.go declaring NullOrderID, with fields OrderID, Valid
+// and Set, its NewNullOrderID, NullOrderIDFrom and NullOrderIDFromPtr
+// constructors, and the IsValid, IsSet, IsZero, SetValid, SetNull and Ptr
+// methods. It implements json.Marshaler, json.Unmarshaler, the encoding
+// text, binary and gob interfaces, the XML element and attribute
+// interfaces, sql.Scanner and driver.Valuer, and the YAML, CBOR and
+// MessagePack marshalers nullint64 supports, by converting to and from the
+// nullint64 type for the base, so it follows the same parsing and
+// marshaling rules and package-level options.
+//
+// The base is the underlying type of -type and one of int64, int32, int16,
+// int8, uint64, uint32, float64, string, bool or time.Time. The package
+// defaults to $GOPACKAGE, which go generate sets.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// bases maps each supported base type to the nullint64 type wrapping it,
+// which is also the name of that type's value field.
+var bases = map[string]string{
+	"int64":     "Int64",
+	"int32":     "Int32",
+	"int16":     "Int16",
+	"int8":      "Int8",
+	"uint64":    "Uint64",
+	"uint32":    "Uint32",
+	"float64":   "Float64",
+	"string":    "String",
+	"bool":      "Bool",
+	"time.Time": "Time",
+}
+
+func main() {
+	typ := flag.String("type", "", "named type to wrap (required)")
+	base := flag.String("base", "", "underlying type of -type (required)")
+	name := flag.String("name", "", "name of the generated type (default Null<type>)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file")
+	output := flag.String("output", "", "output file (default <type>_null.go)")
+	flag.Parse()
+
+	if *typ == "" || *base == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *name == "" {
+		*name = "Null" + *typ
+	}
+	if *output == "" {
+		*output = strings.ToLower(*typ) + "_null.go"
+	}
+
+	src, err := generate(*pkg, *typ, *base, *name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "nullgen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "nullgen:", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the formatted source of the wrapper named name.
+func generate(pkg, typ, base, name string) ([]byte, error) {
+	null, ok := bases[base]
+	if !ok {
+		return nil, fmt.Errorf("unsupported base type %q", base)
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]string{
+		"Package": pkg,
+		"Type":    typ,
+		"Base":    base,
+		"Name":    name,
+		"Null":    null,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by nullgen -type {{.Type}} -base {{.Base}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+{{- if eq .Base "time.Time"}}
+	"time"
+{{- end}}
+
+	"github.com/ccakes/nullint64"
+)
+
+// {{.Name}} is a nullable {{.Type}} with the Valid/Set semantics of
+// nullint64.{{.Null}}.
+type {{.Name}} struct {
+	{{.Type}} {{.Type}}
+	Valid bool
+	Set bool
+}
+
+// New{{.Name}} creates a new {{.Name}}.
+func New{{.Name}}(v {{.Type}}, valid bool) {{.Name}} {
+	return {{.Name}}{ {{- .Type}}: v, Valid: valid, Set: true}
+}
+
+// {{.Name}}From creates a new {{.Name}} that will always be valid.
+func {{.Name}}From(v {{.Type}}) {{.Name}} {
+	return New{{.Name}}(v, true)
+}
+
+// {{.Name}}FromPtr creates a new {{.Name}} that will be null if v is nil.
+func {{.Name}}FromPtr(v *{{.Type}}) {{.Name}} {
+	if v == nil {
+		return {{.Name}}{Set: true}
+	}
+	return New{{.Name}}(*v, true)
+}
+
+func (n {{.Name}}) null() nullint64.{{.Null}} {
+	return nullint64.{{.Null}}{ {{- .Null}}: {{.Base}}(n.{{.Type}}), Valid: n.Valid, Set: n.Set}
+}
+
+func (n *{{.Name}}) setNull(v nullint64.{{.Null}}) {
+	n.{{.Type}}, n.Valid, n.Set = {{.Type}}(v.{{.Null}}), v.Valid, v.Set
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (n {{.Name}}) IsValid() bool {
+	return n.Set && n.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (n {{.Name}}) IsSet() bool {
+	return n.Set
+}
+
+// IsZero reports whether n is omitted by omitempty, as for
+// nullint64.{{.Null}}.
+func (n {{.Name}}) IsZero() bool {
+	return n.null().IsZero()
+}
+
+// SetValid changes this {{.Name}}'s value and also sets it to be non-null.
+func (n *{{.Name}}) SetValid(v {{.Type}}) {
+	n.{{.Type}}, n.Valid, n.Set = v, true, true
+}
+
+// SetNull sets this {{.Name}} to an explicit null.
+func (n *{{.Name}}) SetNull() {
+	*n = {{.Name}}{Set: true}
+}
+
+// Ptr returns a pointer to this {{.Name}}'s value, or a nil pointer if it is
+// null.
+func (n {{.Name}}) Ptr() *{{.Type}} {
+	if !n.Valid {
+		return nil
+	}
+	return &n.{{.Type}}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n {{.Name}}) MarshalJSON() ([]byte, error) {
+	return n.null().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *{{.Name}}) UnmarshalJSON(data []byte) error {
+	var v nullint64.{{.Null}}
+	err := v.UnmarshalJSON(data)
+	n.setNull(v)
+	return err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (n {{.Name}}) MarshalText() ([]byte, error) {
+	return n.null().MarshalText()
+}
+
+// AppendText implements encoding.TextAppender.
+func (n {{.Name}}) AppendText(b []byte) ([]byte, error) {
+	return n.null().AppendText(b)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (n *{{.Name}}) UnmarshalText(text []byte) error {
+	var v nullint64.{{.Null}}
+	err := v.UnmarshalText(text)
+	n.setNull(v)
+	return err
+}
+
+// Scan implements the Scanner interface.
+func (n *{{.Name}}) Scan(value interface{}) error {
+	var v nullint64.{{.Null}}
+	err := v.Scan(value)
+	n.setNull(v)
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (n {{.Name}}) Value() (driver.Value, error) {
+	return n.null().Value()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (n {{.Name}}) MarshalBinary() ([]byte, error) {
+	return n.null().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (n *{{.Name}}) UnmarshalBinary(data []byte) error {
+	var v nullint64.{{.Null}}
+	err := v.UnmarshalBinary(data)
+	n.setNull(v)
+	return err
+}
+
+// GobEncode implements gob.GobEncoder.
+func (n {{.Name}}) GobEncode() ([]byte, error) {
+	return n.null().GobEncode()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (n *{{.Name}}) GobDecode(data []byte) error {
+	var v nullint64.{{.Null}}
+	err := v.GobDecode(data)
+	n.setNull(v)
+	return err
+}
+
+// MarshalXML implements xml.Marshaler.
+func (n {{.Name}}) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return n.null().MarshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (n *{{.Name}}) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v nullint64.{{.Null}}
+	err := v.UnmarshalXML(d, start)
+	n.setNull(v)
+	return err
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (n {{.Name}}) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return n.null().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (n *{{.Name}}) UnmarshalXMLAttr(attr xml.Attr) error {
+	var v nullint64.{{.Null}}
+	err := v.UnmarshalXMLAttr(attr)
+	n.setNull(v)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (n {{.Name}}) MarshalYAML() (interface{}, error) {
+	return n.null().MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (n *{{.Name}}) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v nullint64.{{.Null}}
+	err := v.UnmarshalYAML(unmarshal)
+	n.setNull(v)
+	return err
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (n {{.Name}}) MarshalCBOR() ([]byte, error) {
+	return n.null().MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (n *{{.Name}}) UnmarshalCBOR(data []byte) error {
+	var v nullint64.{{.Null}}
+	err := v.UnmarshalCBOR(data)
+	n.setNull(v)
+	return err
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+func (n {{.Name}}) MarshalMsgpack() ([]byte, error) {
+	return n.null().MarshalMsgpack()
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+func (n *{{.Name}}) UnmarshalMsgpack(data []byte) error {
+	var v nullint64.{{.Null}}
+	err := v.UnmarshalMsgpack(data)
+	n.setNull(v)
+	return err
+}
+`))
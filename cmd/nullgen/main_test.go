@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// methods are the methods every generated wrapper must have.
+var methods = []string{
+	"MarshalJSON", "UnmarshalJSON", "MarshalText", "UnmarshalText", "AppendText",
+	"Scan", "Value", "MarshalBinary", "UnmarshalBinary", "GobEncode", "GobDecode",
+	"MarshalXML", "UnmarshalXML", "MarshalXMLAttr", "UnmarshalXMLAttr",
+	"MarshalYAML", "UnmarshalYAML", "MarshalCBOR", "UnmarshalCBOR",
+	"MarshalMsgpack", "UnmarshalMsgpack",
+}
+
+func TestGenerate(t *testing.T) {
+	for base := range bases {
+		t.Run(base, func(t *testing.T) {
+			src, err := generate("models", "ID", base, "NullID")
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+			for _, m := range methods {
+				if !strings.Contains(string(src), ") "+m+"(") {
+					t.Errorf("missing %s", m)
+				}
+			}
+		})
+	}
+}
+
+// checks asserts, in the generated test module, that a wrapper implements
+// the interfaces its methods promise.
+const checks = `package models
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+)
+
+type encoder interface {
+	json.Marshaler
+	encoding.TextMarshaler
+	AppendText(b []byte) ([]byte, error)
+	encoding.BinaryMarshaler
+	gob.GobEncoder
+	xml.Marshaler
+	xml.MarshalerAttr
+	MarshalYAML() (interface{}, error)
+	MarshalCBOR() ([]byte, error)
+	MarshalMsgpack() ([]byte, error)
+	driver.Valuer
+}
+
+type decoder interface {
+	json.Unmarshaler
+	encoding.TextUnmarshaler
+	encoding.BinaryUnmarshaler
+	gob.GobDecoder
+	xml.Unmarshaler
+	xml.UnmarshalerAttr
+	UnmarshalYAML(unmarshal func(interface{}) error) error
+	UnmarshalCBOR(data []byte) error
+	UnmarshalMsgpack(data []byte) error
+	sql.Scanner
+}
+`
+
+// TestGeneratedCodeCompiles generates a wrapper for every base into a
+// scratch module that uses this checkout of nullint64, and vets it.
+func TestGeneratedCodeCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go vet of generated code in short mode")
+	}
+	gotool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	gomod := fmt.Sprintf("module example.com/models\n\ngo 1.17\n\nrequire github.com/ccakes/nullint64 v0.0.0\n\nreplace github.com/ccakes/nullint64 => %s\n", root)
+	writeFile(t, filepath.Join(dir, "go.mod"), gomod)
+
+	var names []string
+	for base := range bases {
+		names = append(names, base)
+	}
+	sort.Strings(names)
+	types := "package models\n\nimport \"time\"\n\nvar _ time.Time\n\n"
+	assertions := "\nvar (\n"
+	for k, base := range names {
+		typ := fmt.Sprintf("ID%d", k)
+		types += fmt.Sprintf("type %s %s\n", typ, base)
+		assertions += fmt.Sprintf("\t_ encoder = Null%s{}\n\t_ decoder = (*Null%s)(nil)\n", typ, typ)
+
+		src, err := generate("models", typ, base, "Null"+typ)
+		if err != nil {
+			t.Fatalf("generate %s: %v", base, err)
+		}
+		writeFile(t, filepath.Join(dir, strings.ToLower(typ)+"_null.go"), string(src))
+	}
+	writeFile(t, filepath.Join(dir, "types.go"), types)
+	writeFile(t, filepath.Join(dir, "checks.go"), checks+assertions+")\n")
+
+	cmd := exec.Command(gotool, "vet", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod", "GOPROXY=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet of generated code: %v\n%s", err, out)
+	}
+}
+
+func writeFile(t *testing.T, name, data string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateUnsupportedBase(t *testing.T) {
+	if _, err := generate("models", "ID", "complex128", "NullID"); err == nil {
+		t.Error("generate with base complex128 succeeded")
+	}
+}
@@ -0,0 +1,112 @@
+package nullint64
+
+import "sort"
+
+// SortNullsLast makes Compare, and so SortSlice, order nulls after every
+// valid value instead of before.
+var SortNullsLast = false
+
+// WeakEqual reports whether i and other hold the same Valid flag and Int64
+// value, ignoring Set. It suits comparing a freshly decoded value with one
+// loaded from a database, where the Set flags may legitimately differ.
+func (i Int64) WeakEqual(other Int64) bool {
+	return i.Valid == other.Valid && i.Int64 == other.Int64
+}
+
+// StrictEqual reports whether i and other are identical in every field,
+// including Set.
+func (i Int64) StrictEqual(other Int64) bool {
+	return i == other
+}
+
+// Equal reports whether i and other are in the same State and, if valid,
+// hold the same value. Unlike WeakEqual it tells an unset Int64 from an
+// explicit null; unlike StrictEqual it ignores the Int64 field of nulls.
+func (i Int64) Equal(other Int64) bool {
+	if i.State() != other.State() {
+		return false
+	}
+	return i.State() != StateValid || i.Int64 == other.Int64
+}
+
+// OrderKey returns the value and validity of this Int64 for use in
+// composite sort keys. Ordering by valid first (false before true) and then
+// by value sorts nulls first. Nulls always yield a value of 0, so they
+// compare equal among themselves. There is deliberately no single-int64
+// key: mapping null to math.MinInt64 would make it collide with a genuine
+// math.MinInt64.
+func (i Int64) OrderKey() (int64, bool) {
+	if !i.Valid {
+		return 0, false
+	}
+	return i.Int64, true
+}
+
+// CompareNullsFirst returns -1, 0 or +1 as a sorts before, equal to or
+// after b, with nulls before every valid value. Its signature suits
+// slices.SortStableFunc and slices.SortFunc.
+func CompareNullsFirst(a, b Int64) int {
+	av, aok := a.OrderKey()
+	bv, bok := b.OrderKey()
+	switch {
+	case aok != bok:
+		if !aok {
+			return -1
+		}
+		return 1
+	case av < bv:
+		return -1
+	case av > bv:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Merge applies patch to base with the semantics of a JSON Merge Patch
+// (RFC 7396) member: an unset patch leaves base as it is, an explicit null
+// clears it to null, and a value replaces it. The result is Set if either
+// input is.
+func Merge(base, patch Int64) Int64 {
+	if !patch.Set {
+		return base
+	}
+	return patch
+}
+
+// Merge3 reconciles local and remote edits of a value that both started
+// from base. If only one side differs from base that side wins, and if
+// both changed to the same value that value wins; ok is false if they
+// changed to different values, which the caller must resolve. Values are
+// compared with WeakEqual, so a difference in Set alone is not a change.
+func Merge3(base, local, remote Int64) (merged Int64, ok bool) {
+	switch {
+	case local.WeakEqual(remote):
+		return local, true
+	case local.WeakEqual(base):
+		return remote, true
+	case remote.WeakEqual(base):
+		return local, true
+	default:
+		return Int64{}, false
+	}
+}
+
+// Compare returns -1, 0 or +1 as i sorts before, equal to or after other.
+// Nulls, including unset values, sort before every valid value, or after
+// if SortNullsLast is set, and compare equal among themselves.
+func (i Int64) Compare(other Int64) int {
+	c := CompareNullsFirst(i, other)
+	if SortNullsLast && i.Valid != other.Valid {
+		c = -c
+	}
+	return c
+}
+
+// SortSlice sorts vs in place in the order of Compare. The sort is stable,
+// so nulls keep their relative order.
+func SortSlice(vs []Int64) {
+	sort.SliceStable(vs, func(a, b int) bool {
+		return vs[a].Compare(vs[b]) < 0
+	})
+}
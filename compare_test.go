@@ -0,0 +1,92 @@
+package nullint64
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestEqualities(t *testing.T) {
+	garbageNull := Int64{Int64: 5, Set: true}
+	tests := []struct {
+		name                string
+		a, b                Int64
+		weak, strict, equal bool
+	}{
+		{"same value", Int64From(1), Int64From(1), true, true, true},
+		{"different value", Int64From(1), Int64From(2), false, false, false},
+		{"null vs unset", NewInt64(0, false), Int64{}, true, false, false},
+		{"valid vs unset-flag valid", Int64From(1), Int64{Int64: 1, Valid: true}, true, false, false},
+		{"zero vs null", Int64From(0), NewInt64(0, false), false, false, false},
+		{"nulls with residue", garbageNull, NewInt64(0, false), false, false, true},
+	}
+	for _, tt := range tests {
+		if got := tt.a.WeakEqual(tt.b); got != tt.weak {
+			t.Errorf("%s: WeakEqual = %v, want %v", tt.name, got, tt.weak)
+		}
+		if got := tt.a.StrictEqual(tt.b); got != tt.strict {
+			t.Errorf("%s: StrictEqual = %v, want %v", tt.name, got, tt.strict)
+		}
+		if got := tt.a.Equal(tt.b); got != tt.equal {
+			t.Errorf("%s: Equal = %v, want %v", tt.name, got, tt.equal)
+		}
+	}
+}
+
+func TestOrderKey(t *testing.T) {
+	tests := []struct {
+		in     Int64
+		want   int64
+		wantOK bool
+	}{
+		{Int64From(math.MinInt64), math.MinInt64, true},
+		{Int64From(0), 0, true},
+		{NewInt64(0, false), 0, false},
+		{Int64{Int64: 9, Set: true}, 0, false},
+	}
+	for _, tt := range tests {
+		v, ok := tt.in.OrderKey()
+		if v != tt.want || ok != tt.wantOK {
+			t.Errorf("OrderKey(%+v) = %d, %v, want %d, %v", tt.in, v, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCompareNullsFirst(t *testing.T) {
+	vs := []Int64{Int64From(3), NewInt64(0, false), Int64From(math.MinInt64), {Int64: 9, Set: true}, Int64From(-1)}
+	sort.SliceStable(vs, func(a, b int) bool { return CompareNullsFirst(vs[a], vs[b]) < 0 })
+	want := []Int64{NewInt64(0, false), {Int64: 9, Set: true}, Int64From(math.MinInt64), Int64From(-1), Int64From(3)}
+	if !reflect.DeepEqual(vs, want) {
+		t.Errorf("sorted = %+v, want %+v", vs, want)
+	}
+	if got := CompareNullsFirst(NewInt64(0, false), Int64{Int64: 9}); got != 0 {
+		t.Errorf("CompareNullsFirst of two nulls = %d, want 0", got)
+	}
+}
+
+func TestMerge3(t *testing.T) {
+	one, two, three := Int64From(1), Int64From(2), Int64From(3)
+	null := NewInt64(0, false)
+	tests := []struct {
+		name                string
+		base, local, remote Int64
+		want                Int64
+		wantOK              bool
+	}{
+		{"unchanged", one, one, one, one, true},
+		{"local change", one, two, one, two, true},
+		{"remote change", one, one, two, two, true},
+		{"same change", one, two, two, two, true},
+		{"conflict", one, two, three, Int64{}, false},
+		{"remote cleared", one, one, null, null, true},
+		{"clear vs change", one, null, two, Int64{}, false},
+		{"set alone is no change", Int64{}, null, one, one, true},
+	}
+	for _, tt := range tests {
+		got, ok := Merge3(tt.base, tt.local, tt.remote)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("%s: Merge3 = %+v, %v, want %+v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package nullint64
+
+import "database/sql"
+
+// NullInt64 is an alias of Int64 to ease migration from sql.NullInt64: code
+// using sql.NullInt64 compiles against this package after swapping the
+// import, and gains every Int64 method.
+//
+// Differences from sql.NullInt64 to be aware of:
+//
+//   - Int64 carries a third field, Set, recording whether a value (possibly
+//     null) was explicitly supplied. Composite literals such as
+//     NullInt64{Int64: 1, Valid: true} leave Set false; use NewInt64 or
+//     Int64From so IsValid and IsSet report what you expect.
+//   - Int64 implements json.Marshaler, json.Unmarshaler and the encoding
+//     text interfaces, so it encodes as a bare number or null rather than
+//     as an object with Int64 and Valid keys.
+type NullInt64 = Int64
+
+// FromNullInt64 creates a new Int64 from n, set and null if n is not Valid.
+func FromNullInt64(n sql.NullInt64) Int64 {
+	if !n.Valid {
+		return NewInt64(0, false)
+	}
+	return Int64From(n.Int64)
+}
+
+// NullInt64 converts this Int64 to a sql.NullInt64. Unset and null both
+// convert to an invalid sql.NullInt64.
+func (i Int64) NullInt64() sql.NullInt64 {
+	if !i.Valid {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: i.Int64, Valid: true}
+}
+
+// ToOption returns this Int64 in the (value, ok) form used by Go optional
+// types such as samber/mo's Option: ok is false if this Int64 is null.
+func (i Int64) ToOption() (int64, bool) {
+	if !i.Valid {
+		return 0, false
+	}
+	return i.Int64, true
+}
+
+// FromOption creates a new Int64 from the (value, ok) form returned by
+// optional types; it is null if ok is false.
+func FromOption(v int64, ok bool) Int64 {
+	if !ok {
+		return NewInt64(0, false)
+	}
+	return Int64From(v)
+}
+
+// Map returns a valid Int64 holding f applied to the value of this Int64,
+// or null without calling f if this Int64 is null.
+func (i Int64) Map(f func(int64) int64) Int64 {
+	if !i.Valid {
+		return NewInt64(0, false)
+	}
+	return Int64From(f(i.Int64))
+}
+
+// FlatMap is like Map, but since f returns an Int64 it may itself yield
+// null, as a failed lookup would, letting fallible steps be chained.
+func (i Int64) FlatMap(f func(int64) Int64) Int64 {
+	if !i.Valid {
+		return NewInt64(0, false)
+	}
+	if r := f(i.Int64); r.Valid {
+		return r
+	}
+	return NewInt64(0, false)
+}
+
+// Or returns the value of this Int64, or fallback if it is null.
+func (i Int64) Or(fallback int64) int64 {
+	if !i.Valid {
+		return fallback
+	}
+	return i.Int64
+}
+
+// ValueOr is Or under the name used by guregu/null.
+func (i Int64) ValueOr(def int64) int64 {
+	return i.Or(def)
+}
+
+// ValueOrZero returns the value of this Int64, or 0 if it is null, as
+// guregu/null's method of the same name does.
+func (i Int64) ValueOrZero() int64 {
+	return i.Or(0)
+}
+
+// OrElse returns this Int64 if it is valid, and other otherwise.
+func (i Int64) OrElse(other Int64) Int64 {
+	if !i.Valid {
+		return other
+	}
+	return i
+}
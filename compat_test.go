@@ -0,0 +1,92 @@
+package nullint64
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullInt64Alias(t *testing.T) {
+	var n NullInt64 = Int64From(3)
+	var i Int64 = n
+	if !i.IsValid() || i.Int64 != 3 {
+		t.Errorf("alias conversion = %+v", i)
+	}
+
+	// A sql.NullInt64-style literal leaves Set false.
+	lit := NullInt64{Int64: 1, Valid: true}
+	if lit.IsValid() || lit.IsSet() {
+		t.Errorf("literal IsValid = %v, IsSet = %v, want false", lit.IsValid(), lit.IsSet())
+	}
+
+	b, err := json.Marshal(struct{ N NullInt64 }{Int64From(7)})
+	if err != nil || string(b) != `{"N":7}` {
+		t.Errorf("json.Marshal = %s, %v", b, err)
+	}
+}
+
+func TestOption(t *testing.T) {
+	tests := []struct {
+		in     Int64
+		want   int64
+		wantOK bool
+	}{
+		{Int64From(4), 4, true},
+		{Int64From(0), 0, true},
+		{NewInt64(0, false), 0, false},
+		{Int64{Int64: 9, Set: true}, 0, false},
+	}
+	for _, tt := range tests {
+		v, ok := tt.in.ToOption()
+		if v != tt.want || ok != tt.wantOK {
+			t.Errorf("ToOption(%+v) = %d, %v, want %d, %v", tt.in, v, ok, tt.want, tt.wantOK)
+		}
+		if back := FromOption(v, ok); !back.Equal(tt.in) && tt.wantOK {
+			t.Errorf("FromOption(%d, %v) = %+v, want %+v", v, ok, back, tt.in)
+		}
+	}
+	if got := FromOption(7, false); got != NewInt64(0, false) {
+		t.Errorf("FromOption(7, false) = %+v, want null", got)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	ids := map[int64]int64{1: 100}
+	lookup := func(k int64) Int64 {
+		if v, ok := ids[k]; ok {
+			return Int64From(v)
+		}
+		return NewInt64(0, false)
+	}
+	calls := 0
+	counted := func(k int64) Int64 {
+		calls++
+		return lookup(k)
+	}
+	tests := []struct {
+		name string
+		in   Int64
+		want Int64
+	}{
+		{"found", Int64From(1), Int64From(100)},
+		{"missing", Int64From(2), NewInt64(0, false)},
+		{"null", NewInt64(0, false), NewInt64(0, false)},
+		{"unset", Int64{}, NewInt64(0, false)},
+	}
+	for _, tt := range tests {
+		if got := tt.in.FlatMap(counted); got != tt.want {
+			t.Errorf("%s: FlatMap = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("f called %d times, want 2", calls)
+	}
+
+	unsetResult := Int64From(1).FlatMap(func(int64) Int64 { return Int64{} })
+	if unsetResult != NewInt64(0, false) {
+		t.Errorf("FlatMap to unset = %+v, want explicit null", unsetResult)
+	}
+	chained := Int64From(1).FlatMap(lookup).FlatMap(lookup)
+	if chained.Valid {
+		t.Errorf("chained FlatMap = %+v, want null", chained)
+	}
+}
@@ -0,0 +1,83 @@
+package nullint64
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentUse runs the encoding, decoding and scanning paths from
+// many goroutines at once, each on its own values, while the package
+// options stay fixed. Run with -race: any write to package-level state
+// from these methods is reported as a data race.
+func TestConcurrentUse(t *testing.T) {
+	shared := Int64From(42)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for k := 0; k < 200; k++ {
+				n := int64(g*1000 + k)
+
+				b, err := Int64From(n).MarshalJSON()
+				if err != nil {
+					errs <- err
+					return
+				}
+				var j Int64
+				if err := j.UnmarshalJSON(b); err != nil || j.Int64 != n {
+					errs <- fmt.Errorf("UnmarshalJSON(%s) = %+v, %v", b, j, err)
+					return
+				}
+
+				var s Int64
+				if err := s.Scan(strconv.FormatInt(n, 10)); err != nil || s.Int64 != n {
+					errs <- fmt.Errorf("Scan(%d) = %+v, %v", n, s, err)
+					return
+				}
+				if err := s.Scan(nil); err != nil || s.Valid {
+					errs <- fmt.Errorf("Scan(nil) = %+v, %v", s, err)
+					return
+				}
+
+				// Concurrent reads of a single value are allowed.
+				if _, err := shared.MarshalJSON(); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent use: %v", err)
+	}
+}
+
+func TestAtomicInt64Concurrent(t *testing.T) {
+	var a AtomicInt64
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				for {
+					old := a.Load()
+					if a.CompareAndSwap(old, Int64From(old.Int64+1)) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if got := a.Load(); got != Int64From(800) {
+		t.Errorf("Load() = %+v, want 800", got)
+	}
+}
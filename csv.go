@@ -0,0 +1,42 @@
+package nullint64
+
+import "strconv"
+
+// MarshalCSV implements the TypeMarshaller interface of gocarina/gocsv,
+// writing the cell as MarshalText does: the value, or TextNullToken,
+// empty by default, for null and unset alike. It needs no import of the
+// gocsv package.
+func (i Int64) MarshalCSV() (string, error) {
+	text, err := i.MarshalText()
+	return string(text), err
+}
+
+// UnmarshalCSV implements the TypeUnmarshaller interface of
+// gocarina/gocsv, parsing the cell as UnmarshalText does, so an empty cell
+// or TextNullToken decodes as null.
+func (i *Int64) UnmarshalCSV(s string) error {
+	return i.UnmarshalText([]byte(s))
+}
+
+// CSVFields formats vs as cells for an encoding/csv record, as MarshalCSV
+// formats each one.
+func CSVFields(vs []Int64) []string {
+	out := make([]string, len(vs))
+	for k, v := range vs {
+		out[k], _ = v.MarshalCSV()
+	}
+	return out
+}
+
+// ParseCSVFields parses the cells of an encoding/csv record as UnmarshalCSV
+// does, stopping at the first cell that fails. The error is a *FieldError
+// naming the cell's zero-based column.
+func ParseCSVFields(record []string) ([]Int64, error) {
+	out := make([]Int64, len(record))
+	for k, s := range record {
+		if err := out[k].UnmarshalCSV(s); err != nil {
+			return nil, &FieldError{Field: strconv.Itoa(k), Err: err}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,157 @@
+// Package decimalnull provides a nullable shopspring/decimal.Decimal with
+// the Valid/Set semantics of nullint64.Int64, for monetary and other
+// fixed-point columns.
+//
+// Parsing and formatting are those of decimal.Decimal, including its
+// package-level options such as decimal.MarshalJSONWithoutQuotes; nulls
+// are encoded as for nullint64.Int64.
+package decimalnull
+
+import (
+	"bytes"
+	"database/sql/driver"
+
+	"github.com/ccakes/nullint64"
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is a nullable decimal.Decimal.
+type Decimal struct {
+	Decimal decimal.Decimal
+	Valid   bool
+	Set     bool
+}
+
+// New creates a new Decimal
+func New(d decimal.Decimal, valid bool) Decimal {
+	if !valid {
+		d = decimal.Decimal{}
+	}
+	return Decimal{
+		Decimal: d,
+		Valid:   valid,
+		Set:     true,
+	}
+}
+
+// From creates a new Decimal that will always be valid.
+func From(d decimal.Decimal) Decimal {
+	return New(d, true)
+}
+
+// FromPtr creates a new Decimal that will be null if d is nil.
+func FromPtr(d *decimal.Decimal) Decimal {
+	if d == nil {
+		return New(decimal.Decimal{}, false)
+	}
+	return New(*d, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (d Decimal) IsValid() bool {
+	return d.Set && d.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (d Decimal) IsSet() bool {
+	return d.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts JSON numbers and
+// decimal strings, and decodes null and the empty string as null.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	d.Set = true
+	d.Decimal, d.Valid = decimal.Decimal{}, false
+	if bytes.Equal(data, nullint64.NullBytes) || bytes.Equal(data, []byte(`""`)) {
+		return nil
+	}
+	if err := d.Decimal.UnmarshalJSON(data); err != nil {
+		d.Decimal = decimal.Decimal{}
+		return err
+	}
+	d.Valid = true
+	return nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text decodes
+// as null.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	d.Set = true
+	d.Decimal, d.Valid = decimal.Decimal{}, false
+	if len(text) == 0 {
+		return nil
+	}
+	if err := d.Decimal.UnmarshalText(text); err != nil {
+		d.Decimal = decimal.Decimal{}
+		return err
+	}
+	d.Valid = true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return nullint64.NewInt64(0, false).MarshalJSON()
+	}
+	return d.Decimal.MarshalJSON()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte{}, nil
+	}
+	return d.Decimal.MarshalText()
+}
+
+// SetValid changes this Decimal's value and also sets it to be non-null.
+func (d *Decimal) SetValid(v decimal.Decimal) {
+	*d = From(v)
+}
+
+// SetNull sets this Decimal to an explicit null.
+func (d *Decimal) SetNull() {
+	*d = New(decimal.Decimal{}, false)
+}
+
+// Ptr returns a pointer to this Decimal's value, or a nil pointer if this
+// Decimal is null.
+func (d Decimal) Ptr() *decimal.Decimal {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Decimal
+}
+
+// IsZero returns true for invalid Decimal's, for omitempty support, or
+// only for unset ones if nullint64.OmitUnsetOnly is set.
+func (d Decimal) IsZero() bool {
+	return nullint64.Int64{Valid: d.Valid, Set: d.Set}.IsZero()
+}
+
+// Scan implements the Scanner interface, accepting what decimal.Decimal's
+// Scan does.
+func (d *Decimal) Scan(value interface{}) error {
+	if value == nil {
+		d.Decimal, d.Valid, d.Set = decimal.Decimal{}, false, true
+		return nil
+	}
+	d.Set = true
+	if err := d.Decimal.Scan(value); err != nil {
+		d.Decimal, d.Valid = decimal.Decimal{}, false
+		return err
+	}
+	d.Valid = true
+	return nil
+}
+
+// Value implements the driver Valuer interface, returning the value as
+// decimal text.
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Decimal.Value()
+}
@@ -0,0 +1,121 @@
+package nullint64
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// FieldError records a failure to decode a single struct field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("nullint64: field %s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalStruct decodes the JSON object in data into the struct pointed
+// to by v one field at a time, returning a *FieldError for every field that
+// fails rather than stopping at the first as json.Unmarshal does. Field
+// names follow encoding/json: the json tag if present, otherwise the field
+// name, matched case-insensitively; fields of embedded structs are
+// promoted. Keys with no matching field are ignored.
+func UnmarshalStruct(data []byte, v interface{}) []error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return []error{errors.New("nullint64: UnmarshalStruct requires a non-nil pointer to a struct")}
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, f := range structFields(rv.Elem(), "json") {
+		raw, ok := fields[f.name]
+		if !ok {
+			for k, r := range fields {
+				if strings.EqualFold(k, f.name) {
+					raw, ok = r, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, f.value.Addr().Interface()); err != nil {
+			errs = append(errs, &FieldError{Field: f.name, Err: err})
+		}
+	}
+	return errs
+}
+
+type structField struct {
+	name  string
+	value reflect.Value
+}
+
+// structFields lists the exported fields of the struct sv under the names
+// given by the tag key, such as "json", flattening untagged embedded
+// structs.
+func structFields(sv reflect.Value, key string) []structField {
+	var out []structField
+	st := sv.Type()
+	for k := 0; k < st.NumField(); k++ {
+		sf := st.Field(k)
+		tag := sf.Tag.Get(key)
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			out = append(out, structFields(sv.Field(k), key)...)
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		out = append(out, structField{name: name, value: sv.Field(k)})
+	}
+	return out
+}
+
+// UnmarshalNDJSON reads newline-delimited JSON from r, decoding each line
+// into an Int64 and passing it to fn. Lines containing null yield a null
+// Int64; blank lines are skipped rather than treated as null, since NDJSON
+// writers commonly emit a trailing newline. It stops at the end of input,
+// returning nil, or at the first decoding or fn error, which it returns.
+func UnmarshalNDJSON(r io.Reader, fn func(Int64) error) error {
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		b := bytes.TrimSpace(sc.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		var i Int64
+		if err := json.Unmarshal(b, &i); err != nil {
+			return fmt.Errorf("nullint64: line %d: %w", line, err)
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
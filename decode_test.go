@@ -0,0 +1,87 @@
+package nullint64
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalStruct(t *testing.T) {
+	type Embedded struct {
+		E Int64 `json:"e"`
+	}
+	type record struct {
+		Embedded
+		A       Int64 `json:"a"`
+		B       Int64 `json:"b"`
+		Name    string
+		Skipped Int64 `json:"-"`
+		hidden  Int64
+	}
+	var r record
+	errs := UnmarshalStruct([]byte(`{"a": "x", "b": 2, "NAME": "n", "e": true, "Skipped": 1, "hidden": 1, "extra": 1}`), &r)
+	if len(errs) != 2 {
+		t.Fatalf("UnmarshalStruct errors = %v, want 2", errs)
+	}
+	fields := map[string]bool{}
+	for _, err := range errs {
+		var fe *FieldError
+		if !errors.As(err, &fe) {
+			t.Fatalf("error %v is not a *FieldError", err)
+		}
+		fields[fe.Field] = true
+	}
+	if !fields["a"] || !fields["e"] {
+		t.Errorf("failed fields = %v, want a and e", fields)
+	}
+	if r.B != Int64From(2) || r.Name != "n" || r.Skipped.Set || r.hidden.Set {
+		t.Errorf("decoded %+v", r)
+	}
+}
+
+func TestUnmarshalStructInvalid(t *testing.T) {
+	var r struct{ A Int64 }
+	if errs := UnmarshalStruct([]byte(`{"A": 1}`), r); len(errs) != 1 {
+		t.Errorf("non-pointer: errors = %v, want 1", errs)
+	}
+	if errs := UnmarshalStruct([]byte(`[1]`), &r); len(errs) != 1 {
+		t.Errorf("non-object: errors = %v, want 1", errs)
+	}
+	if errs := UnmarshalStruct([]byte(`{"A": 1}`), &r); len(errs) != 0 || r.A != Int64From(1) {
+		t.Errorf("valid: = %+v, %v", r, errs)
+	}
+}
+
+func TestUnmarshalNDJSON(t *testing.T) {
+	input := "1\nnull\n\n  \"3\"  \n"
+	var got []Int64
+	err := UnmarshalNDJSON(strings.NewReader(input), func(i Int64) error {
+		got = append(got, i)
+		return nil
+	})
+	want := []Int64{Int64From(1), NewInt64(0, false), Int64From(3)}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalNDJSON = %+v, %v, want %+v", got, err, want)
+	}
+}
+
+func TestUnmarshalNDJSONErrors(t *testing.T) {
+	err := UnmarshalNDJSON(strings.NewReader("1\n\nx\n4\n"), func(Int64) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("decode error = %v, want one naming line 3", err)
+	}
+
+	stop := errors.New("stop")
+	n := 0
+	err = UnmarshalNDJSON(strings.NewReader("1\n2\n3\n"), func(Int64) error {
+		n++
+		if n == 2 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || n != 2 {
+		t.Errorf("callback error: err = %v after %d values, want stop after 2", err, n)
+	}
+}
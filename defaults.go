@@ -0,0 +1,60 @@
+package nullint64
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var int64Type = reflect.TypeOf(Int64{})
+
+// ApplyDefaults sets every Int64 field of the struct pointed to by v that
+// is not Set to the value of its default struct tag, for example
+//
+//	Limit nullint64.Int64 `default:"100"`
+//
+// Fields that are already Set, including explicit nulls, and fields without
+// a default tag are left untouched. Nested structs are processed
+// recursively.
+func ApplyDefaults(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("nullint64: ApplyDefaults requires a non-nil pointer to a struct")
+	}
+	return applyDefaults(rv.Elem())
+}
+
+func applyDefaults(sv reflect.Value) error {
+	st := sv.Type()
+	for k := 0; k < st.NumField(); k++ {
+		sf, fv := st.Field(k), sv.Field(k)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+
+		if sf.Type != int64Type {
+			if sf.Type.Kind() == reflect.Struct {
+				if err := applyDefaults(fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		def, ok := sf.Tag.Lookup("default")
+		if !ok || !fv.CanSet() {
+			continue
+		}
+		i := fv.Addr().Interface().(*Int64)
+		if i.Set {
+			continue
+		}
+		n, err := strconv.ParseInt(def, 10, 64)
+		if err != nil {
+			return fmt.Errorf("nullint64: field %s: invalid default %q: %w", sf.Name, def, err)
+		}
+		i.SetValid(n)
+	}
+	return nil
+}
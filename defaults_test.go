@@ -0,0 +1,57 @@
+package nullint64
+
+import (
+	"testing"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	type Paging struct {
+		Page Int64 `default:"1"`
+	}
+	type options struct {
+		Paging
+		Limit    Int64 `default:"100"`
+		Offset   Int64 `default:"-5"`
+		Explicit Int64 `default:"7"`
+		Null     Int64 `default:"7"`
+		NoTag    Int64
+		Nested   struct {
+			Depth Int64 `default:"3"`
+		}
+		private Int64 `default:"9"`
+	}
+	o := options{Explicit: Int64From(2), Null: NewInt64(0, false)}
+	if err := ApplyDefaults(&o); err != nil {
+		t.Fatalf("ApplyDefaults: %v", err)
+	}
+	checks := []struct {
+		name      string
+		got, want Int64
+	}{
+		{"Page", o.Page, Int64From(1)},
+		{"Limit", o.Limit, Int64From(100)},
+		{"Offset", o.Offset, Int64From(-5)},
+		{"Explicit", o.Explicit, Int64From(2)},
+		{"Null", o.Null, NewInt64(0, false)},
+		{"NoTag", o.NoTag, Int64{}},
+		{"Nested.Depth", o.Nested.Depth, Int64From(3)},
+		{"private", o.private, Int64{}},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %+v, want %+v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestApplyDefaultsErrors(t *testing.T) {
+	var bad struct {
+		N Int64 `default:"ten"`
+	}
+	if err := ApplyDefaults(&bad); err == nil {
+		t.Error("ApplyDefaults with invalid default succeeded")
+	}
+	if err := ApplyDefaults(bad); err == nil {
+		t.Error("ApplyDefaults with non-pointer succeeded")
+	}
+}
@@ -0,0 +1,16 @@
+// Package nullint64 provides nullable types that, unlike sql.NullInt64,
+// also record whether a value was explicitly set, so "absent", "null" and
+// "a value" can be told apart when decoding JSON, text or database rows.
+//
+// # Concurrency
+//
+// Int64 and the other types in this package are plain values: distinct
+// values may be used from different goroutines freely, and a single value
+// may be read concurrently as long as nothing writes to it. The methods
+// never modify package-level state.
+//
+// The package-level configuration variables, such as NullBytes,
+// MarshalNullAsZero, RelaxedJSON and the OnScanNull and OnScanValue hooks,
+// are read without synchronization. Set them once during program
+// initialization, before any goroutine encodes, decodes or scans a value.
+package nullint64
@@ -0,0 +1,14 @@
+module github.com/ccakes/nullint64/dynamonull
+
+go 1.25.0
+
+require (
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue v1.21.7
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.69.1
+	github.com/ccakes/nullint64 v0.0.0-20261014160539-f823de60508b
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2/service/dynamodbstreams v1.43.0 // indirect
+	github.com/aws/smithy-go v1.28.1 // indirect
+)
@@ -0,0 +1,90 @@
+// Package dynamonull adapts nullint64.Int64 to the attributevalue package
+// of the AWS SDK for Go v2, so values are stored in DynamoDB as an N
+// attribute, or NULL when explicitly null.
+//
+// An attribute missing from an item is never decoded, leaving Set false,
+// while a NULL attribute decodes with Set true and Valid false. The
+// attributevalue encoder can't omit a field through its Marshaler, so an
+// unset Int64 marshals as NULL; use MarshalMap to drop unset fields from
+// an item instead.
+package dynamonull
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ccakes/nullint64"
+)
+
+// Int64 is a nullint64.Int64 implementing attributevalue.Marshaler and
+// attributevalue.Unmarshaler.
+type Int64 struct {
+	nullint64.Int64
+}
+
+// MarshalDynamoDBAttributeValue implements attributevalue.Marshaler.
+func (i Int64) MarshalDynamoDBAttributeValue() (types.AttributeValue, error) {
+	if !i.Valid {
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+	}
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(i.Int64.Int64, 10)}, nil
+}
+
+// UnmarshalDynamoDBAttributeValue implements attributevalue.Unmarshaler.
+// It accepts N attributes holding an integer, and decodes NULL as null.
+func (i *Int64) UnmarshalDynamoDBAttributeValue(av types.AttributeValue) error {
+	switch v := av.(type) {
+	case nil, *types.AttributeValueMemberNULL:
+		i.SetNull()
+		return nil
+	case *types.AttributeValueMemberN:
+		n, err := strconv.ParseInt(v.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("dynamonull: cannot decode N %q into Int64: %w", v.Value, err)
+		}
+		i.SetValid(n)
+		return nil
+	default:
+		return fmt.Errorf("dynamonull: cannot decode %T into Int64", av)
+	}
+}
+
+// MarshalMap marshals the struct in as attributevalue.MarshalMap does,
+// then removes the attributes of its top-level Int64 fields that are not
+// Set, so a partial item only carries the fields that were assigned.
+func MarshalMap(in interface{}) (map[string]types.AttributeValue, error) {
+	item, err := attributevalue.MarshalMap(in)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(in))
+	if rv.Kind() != reflect.Struct {
+		return item, nil
+	}
+	for k := 0; k < rv.NumField(); k++ {
+		sf := rv.Type().Field(k)
+		if sf.PkgPath != "" {
+			continue
+		}
+		f, ok := rv.Field(k).Interface().(Int64)
+		if !ok || f.Set {
+			continue
+		}
+		name := strings.Split(sf.Tag.Get("dynamodbav"), ",")[0]
+		if name == "" {
+			name = sf.Name
+		}
+		delete(item, name)
+	}
+	return item, nil
+}
+
+var (
+	_ attributevalue.Marshaler   = Int64{}
+	_ attributevalue.Unmarshaler = (*Int64)(nil)
+)
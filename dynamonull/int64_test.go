@@ -0,0 +1,84 @@
+package dynamonull
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/ccakes/nullint64"
+)
+
+type item struct {
+	ID      string `dynamodbav:"id"`
+	Count   Int64  `dynamodbav:"count"`
+	Limit   Int64  `dynamodbav:"limit,omitempty"`
+	Quota   Int64
+	private int
+}
+
+func TestMarshalMap(t *testing.T) {
+	in := item{
+		ID:      "a",
+		Count:   Int64{nullint64.Int64From(3)},
+		Quota:   Int64{nullint64.NewInt64(0, false)},
+		private: 1,
+	}
+	got, err := MarshalMap(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n, ok := got["count"].(*types.AttributeValueMemberN); !ok || n.Value != "3" {
+		t.Errorf("count = %#v, want N 3", got["count"])
+	}
+	if _, ok := got["Quota"].(*types.AttributeValueMemberNULL); !ok {
+		t.Errorf("Quota = %#v, want NULL", got["Quota"])
+	}
+	if av, ok := got["limit"]; ok {
+		t.Errorf("unset limit marshaled as %#v, want omitted", av)
+	}
+
+	// A pointer to the struct behaves the same.
+	if got, err := MarshalMap(&in); err != nil || len(got) != 3 {
+		t.Errorf("MarshalMap(&in) = %v, %v; want 3 attributes", got, err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   nullint64.Int64
+		want nullint64.Int64
+	}{
+		{"valid", nullint64.Int64From(-42), nullint64.Int64From(-42)},
+		{"zero", nullint64.Int64From(0), nullint64.Int64From(0)},
+		{"null", nullint64.NewInt64(0, false), nullint64.NewInt64(0, false)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			av, err := attributevalue.Marshal(Int64{tt.in})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got Int64
+			if err := attributevalue.Unmarshal(av, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Int64 != tt.want {
+				t.Errorf("got %#v, want %#v", got.Int64, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for _, av := range []types.AttributeValue{
+		&types.AttributeValueMemberN{Value: "1.5"},
+		&types.AttributeValueMemberS{Value: "1"},
+	} {
+		var got Int64
+		if err := got.UnmarshalDynamoDBAttributeValue(av); err == nil {
+			t.Errorf("UnmarshalDynamoDBAttributeValue(%#v) succeeded", av)
+		}
+	}
+}
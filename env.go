@@ -0,0 +1,56 @@
+package nullint64
+
+import (
+	"os"
+	"reflect"
+)
+
+// Int64FromEnv returns the value of the environment variable key parsed
+// as UnmarshalText parses text. It is unset if the variable is not
+// defined at all and null if it is empty. A value that doesn't parse is
+// also returned as null; use os.LookupEnv and UnmarshalText directly where
+// the error matters.
+func Int64FromEnv(key string) Int64 {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return Int64{}
+	}
+	var i Int64
+	if err := i.UnmarshalText([]byte(v)); err != nil {
+		return NewInt64(0, false)
+	}
+	return i
+}
+
+// DecodeHook returns a hook for github.com/mitchellh/mapstructure, to be
+// set as DecoderConfig.DecodeHook, that decodes config values into Int64
+// fields instead of letting mapstructure fill in their fields directly.
+// Strings are parsed as UnmarshalText parses them, other values as Scan
+// converts them, and nil decodes as null. Keys missing from the input
+// never reach the hook, so their fields stay unset. mapstructure also
+// skips nil values without calling hooks, so explicit nulls stay unset
+// too unless the decoder is configured to pass them on, as with DecodeNil
+// in github.com/go-viper/mapstructure/v2.
+func DecodeHook() func(from, to reflect.Type, data interface{}) (interface{}, error) {
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if to != int64Type {
+			return data, nil
+		}
+		var i Int64
+		switch v := data.(type) {
+		case Int64:
+			return v, nil
+		case nil:
+			return NewInt64(0, false), nil
+		case string:
+			if err := i.UnmarshalText([]byte(v)); err != nil {
+				return nil, err
+			}
+		default:
+			if err := i.Scan(v); err != nil {
+				return nil, err
+			}
+		}
+		return i, nil
+	}
+}
@@ -0,0 +1,69 @@
+// Package fakenull registers a gofakeit function generating
+// nullint64.Int64 test data.
+//
+// gofakeit fills an untagged Int64 field by randomizing Int64, Valid and
+// Set independently, which gives values that are unset yet valid. Tag
+// such fields with the registered function instead:
+//
+//	type Reading struct {
+//		Value nullint64.Int64 `fake:"{nullint64}"`
+//	}
+package fakenull
+
+import (
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/ccakes/nullint64"
+)
+
+// Name is the gofakeit function name Register adds.
+const Name = "nullint64"
+
+// Register adds the nullint64 function to gofakeit. Each value it
+// generates is Set, and is null with probability nullrate, 0 with
+// probability zerorate, and otherwise a random int64 within [min, max].
+// The rates default to 0.2 and 0.1 and the range to [-1000000, 1000000].
+// Like any gofakeit lookup it should be registered before concurrent use.
+func Register() {
+	gofakeit.AddFuncLookup(Name, gofakeit.Info{
+		Display:     "Nullable Int64",
+		Category:    "number",
+		Description: "A nullint64.Int64 mixing null, zero and non-zero values",
+		Example:     "null",
+		Output:      "nullint64.Int64",
+		Params: []gofakeit.Param{
+			{Field: "nullrate", Display: "Null Rate", Type: "float", Default: "0.2", Description: "Probability of a null value"},
+			{Field: "zerorate", Display: "Zero Rate", Type: "float", Default: "0.1", Description: "Probability of a zero value"},
+			{Field: "min", Display: "Min", Type: "int", Default: "-1000000", Description: "Minimum non-null value"},
+			{Field: "max", Display: "Max", Type: "int", Default: "1000000", Description: "Maximum non-null value"},
+		},
+		Generate: generate,
+	})
+}
+
+func generate(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (any, error) {
+	nullRate, err := info.GetFloat64(m, "nullrate")
+	if err != nil {
+		return nil, err
+	}
+	zeroRate, err := info.GetFloat64(m, "zerorate")
+	if err != nil {
+		return nil, err
+	}
+	lo, err := info.GetInt(m, "min")
+	if err != nil {
+		return nil, err
+	}
+	hi, err := info.GetInt(m, "max")
+	if err != nil {
+		return nil, err
+	}
+
+	switch p := f.Float64(); {
+	case p < nullRate:
+		return nullint64.NewInt64(0, false), nil
+	case p < nullRate+zeroRate:
+		return nullint64.Int64From(0), nil
+	default:
+		return nullint64.Int64From(int64(f.IntRange(lo, hi))), nil
+	}
+}
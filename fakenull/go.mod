@@ -0,0 +1,8 @@
+module github.com/ccakes/nullint64/fakenull
+
+go 1.25.0
+
+require (
+	github.com/brianvoe/gofakeit/v7 v7.17.1
+	github.com/ccakes/nullint64 v0.0.0-20261014160539-f823de60508b
+)
@@ -0,0 +1,35 @@
+package nullint64
+
+import "flag"
+
+// Flag returns a flag.Value, also satisfying spf13/pflag's Value, that
+// parses command-line input into i as UnmarshalText does, for use with
+// flag.Var. i is left unset unless the flag is given, so Set tells "not
+// provided" apart from "provided as 0"; an empty argument sets it to null.
+//
+// Int64 can't implement flag.Value itself, as its Set field would clash
+// with the Set method the interface requires.
+func (i *Int64) Flag() flag.Value {
+	return int64Flag{i}
+}
+
+type int64Flag struct {
+	i *Int64
+}
+
+func (f int64Flag) Set(s string) error {
+	return f.i.UnmarshalText([]byte(s))
+}
+
+func (f int64Flag) String() string {
+	if f.i == nil || !f.i.Valid {
+		return ""
+	}
+	text, _ := f.i.MarshalText()
+	return string(text)
+}
+
+// Type implements pflag.Value.
+func (f int64Flag) Type() string {
+	return "int64"
+}
@@ -0,0 +1,208 @@
+package nullint64
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ccakes/nullint64/internal/convert"
+)
+
+// Float64 is a nullable float64 with the same Valid/Set semantics as Int64.
+type Float64 struct {
+	Float64 float64
+	Valid   bool
+	Set     bool
+}
+
+// NewFloat64 creates a new Float64
+func NewFloat64(f float64, valid bool) Float64 {
+	return Float64{
+		Float64: f,
+		Valid:   valid,
+		Set:     true,
+	}
+}
+
+// Float64From creates a new Float64 that will always be valid.
+func Float64From(f float64) Float64 {
+	return NewFloat64(f, true)
+}
+
+// Float64FromPtr creates a new Float64 that will be null if f is nil.
+func Float64FromPtr(f *float64) Float64 {
+	if f == nil {
+		return NewFloat64(0, false)
+	}
+	return NewFloat64(*f, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (f Float64) IsValid() bool {
+	return f.Set && f.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (f Float64) IsSet() bool {
+	return f.Set
+}
+
+// Int64 converts this Float64 to an Int64. ok is false if the value has a
+// fractional part or is outside the range of int64; a null Float64 converts
+// to a null Int64.
+func (f Float64) Int64() (Int64, bool) {
+	if !f.Valid {
+		return Int64{Set: f.Set}, true
+	}
+	// -2^63 is exactly representable as a float64, 2^63 is the first
+	// value past math.MaxInt64.
+	if f.Float64 != math.Trunc(f.Float64) || f.Float64 < math.MinInt64 || f.Float64 >= -math.MinInt64 {
+		return Int64{}, false
+	}
+	return Int64{Int64: int64(f.Float64), Valid: true, Set: f.Set}, true
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *Float64) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(data, NullBytes) {
+		f.Valid = false
+		f.Float64 = 0
+		return nil
+	}
+
+	var (
+		v   interface{}
+		err error
+	)
+	if err := json.Unmarshal(data, &v); err != nil {
+		f.Float64, f.Valid = 0, false
+		return err
+	}
+
+	switch x := v.(type) {
+	case float64:
+		f.Float64 = x
+	case string:
+		if len(x) == 0 {
+			f.Valid = false
+			f.Float64 = 0
+			return nil
+		}
+		f.Float64, err = strconv.ParseFloat(x, 64)
+	case nil:
+		f.Valid = false
+		f.Float64 = 0
+		return nil
+	default:
+		err = fmt.Errorf("nullint64: cannot unmarshal JSON %s into Float64", jsonKind(data))
+	}
+
+	f.Valid = err == nil
+	if !f.Valid {
+		f.Float64 = 0
+	}
+	return err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Float64) UnmarshalText(text []byte) error {
+	f.Set = true
+	if len(text) == 0 || isNullWord(text) {
+		f.Valid = false
+		f.Float64 = 0
+		return nil
+	}
+	var err error
+	f.Float64, err = strconv.ParseFloat(string(text), 64)
+	f.Valid = err == nil
+	if !f.Valid {
+		f.Float64 = 0
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler. Nulls are encoded as for Int64.
+func (f Float64) MarshalJSON() ([]byte, error) {
+	if !f.Valid {
+		return appendNullJSON(nil), nil
+	}
+	if math.IsInf(f.Float64, 0) || math.IsNaN(f.Float64) {
+		return nil, &json.UnsupportedValueError{
+			Str: strconv.FormatFloat(f.Float64, 'g', -1, 64),
+		}
+	}
+	return []byte(strconv.FormatFloat(f.Float64, 'f', -1, 64)), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Float64) MarshalText() ([]byte, error) {
+	if !f.Valid {
+		return []byte(TextNullToken), nil
+	}
+	return []byte(strconv.FormatFloat(f.Float64, 'f', -1, 64)), nil
+}
+
+// AppendText implements encoding.TextAppender, appending the MarshalText
+// form of the value to b.
+func (f Float64) AppendText(b []byte) ([]byte, error) {
+	if !f.Valid {
+		return append(b, TextNullToken...), nil
+	}
+	return strconv.AppendFloat(b, f.Float64, 'f', -1, 64), nil
+}
+
+// SetValid changes this Float64's value and also sets it to be non-null.
+func (f *Float64) SetValid(n float64) {
+	f.Float64 = n
+	f.Valid = true
+	f.Set = true
+}
+
+// SetNull sets this Float64 to an explicit null.
+func (f *Float64) SetNull() {
+	f.Float64 = 0
+	f.Valid = false
+	f.Set = true
+}
+
+// Ptr returns a pointer to this Float64's value, or a nil pointer if this Float64 is null.
+func (f Float64) Ptr() *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+// IsZero returns true for invalid Float64's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (f Float64) IsZero() bool {
+	return isZero(f.Valid, f.Set)
+}
+
+// Scan implements the Scanner interface.
+func (f *Float64) Scan(value interface{}) error {
+	if value == nil || isNilPointer(value) {
+		f.Float64, f.Valid, f.Set = 0, false, true
+		return nil
+	}
+	f.Set = true
+	if err := convert.ConvertAssign(&f.Float64, value); err != nil {
+		f.Float64, f.Valid = 0, false
+		return err
+	}
+	f.Valid = true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (f Float64) Value() (driver.Value, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+	return f.Float64, nil
+}
@@ -0,0 +1,84 @@
+package nullint64
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64Int64(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     Float64
+		want   Int64
+		wantOK bool
+	}{
+		{"integral", Float64From(42), Int64From(42), true},
+		{"negative", Float64From(-3), Int64From(-3), true},
+		{"min int64", Float64From(math.MinInt64), Int64From(math.MinInt64), true},
+		{"fraction", Float64From(1.5), Int64{}, false},
+		{"too large", Float64From(math.Pow(2, 63)), Int64{}, false},
+		{"NaN", Float64From(math.NaN()), Int64{}, false},
+		{"null", NewFloat64(0, false), NewInt64(0, false), true},
+		{"unset", Float64{}, Int64{}, true},
+	}
+	for _, tt := range tests {
+		got, ok := tt.in.Int64()
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("%s: Int64() = %+v, %v, want %+v, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFloat64JSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    Float64
+		wantErr bool
+	}{
+		{`1.5`, Float64From(1.5), false},
+		{`"2.25"`, Float64From(2.25), false},
+		{`""`, NewFloat64(0, false), false},
+		{`null`, NewFloat64(0, false), false},
+		{`true`, NewFloat64(0, false), true},
+		{`"x"`, NewFloat64(0, false), true},
+		{`abc`, NewFloat64(0, false), true},
+		{`42abc`, NewFloat64(0, false), true},
+	}
+	for _, tt := range tests {
+		f := Float64From(9) // a reused value must not keep its old state
+		err := f.UnmarshalJSON([]byte(tt.data))
+		if (err != nil) != tt.wantErr || f != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %+v, %v, want %+v", tt.data, f, err, tt.want)
+		}
+	}
+
+	for _, f := range []Float64{Float64From(1.25), Float64From(-3), NewFloat64(0, false)} {
+		b, err := f.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON(%+v): %v", f, err)
+		}
+		var back Float64
+		if err := back.UnmarshalJSON(b); err != nil || back != f {
+			t.Errorf("round trip of %+v via %s = %+v, %v", f, b, back, err)
+		}
+	}
+	if _, err := Float64From(math.Inf(1)).MarshalJSON(); err == nil {
+		t.Error("MarshalJSON(+Inf) succeeded")
+	}
+}
+
+func TestFloat64Scan(t *testing.T) {
+	var f Float64
+	if err := f.Scan("2.5"); err != nil || f != Float64From(2.5) {
+		t.Errorf("Scan(%q) = %+v, %v", "2.5", f, err)
+	}
+	if err := f.Scan(int64(3)); err != nil || f != Float64From(3) {
+		t.Errorf("Scan(3) = %+v, %v", f, err)
+	}
+	if err := f.Scan(nil); err != nil || f != NewFloat64(0, false) {
+		t.Errorf("Scan(nil) = %+v, %v", f, err)
+	}
+	if v, _ := Float64From(1.5).Value(); v != 1.5 {
+		t.Errorf("Value() = %v, want 1.5", v)
+	}
+}
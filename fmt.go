@@ -0,0 +1,80 @@
+package nullint64
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GoString implements fmt.GoStringer, so %#v prints Go source that
+// reconstructs this Int64.
+func (i Int64) GoString() string {
+	if i.Set {
+		return "nullint64.NewInt64(" + strconv.FormatInt(i.Int64, 10) + ", " + strconv.FormatBool(i.Valid) + ")"
+	}
+
+	var fields []string
+	if i.Int64 != 0 {
+		fields = append(fields, "Int64: "+strconv.FormatInt(i.Int64, 10))
+	}
+	if i.Valid {
+		fields = append(fields, "Valid: true")
+	}
+	return "nullint64.Int64{" + strings.Join(fields, ", ") + "}"
+}
+
+// String implements fmt.Stringer, returning the decimal value, "null" if
+// this Int64 is null, or "<unset>" if it was never set.
+func (i Int64) String() string {
+	switch {
+	case i.Valid:
+		return strconv.FormatInt(i.Int64, 10)
+	case i.Set:
+		return "null"
+	default:
+		return "<unset>"
+	}
+}
+
+// Format implements fmt.Formatter. The integer verbs %d, %b, %o, %O, %x and
+// %X, with their flags, width and precision, format a valid value as they
+// would an int64, while %v and %s print String; null and unset values
+// print as String does for every verb. %#v prints GoString.
+func (i Int64) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if verb == 'v' && f.Flag('#') {
+			io.WriteString(f, i.GoString())
+			return
+		}
+		fmt.Fprintf(f, directive(f, 's', "-"), i.String())
+	case 'd', 'b', 'o', 'O', 'x', 'X':
+		if !i.Valid {
+			fmt.Fprintf(f, directive(f, 's', "-"), i.String())
+			return
+		}
+		fmt.Fprintf(f, directive(f, verb, "+-# 0"), i.Int64)
+	default:
+		fmt.Fprintf(f, "%%!%c(nullint64.Int64=%s)", verb, i.String())
+	}
+}
+
+// directive rebuilds the formatting directive in f for verb, keeping only
+// the given flags. Strings drop all but '-', so a null isn't zero-padded.
+func directive(f fmt.State, verb rune, flags string) string {
+	b := []byte{'%'}
+	for _, flag := range flags {
+		if f.Flag(int(flag)) {
+			b = append(b, byte(flag))
+		}
+	}
+	if w, ok := f.Width(); ok {
+		b = strconv.AppendInt(b, int64(w), 10)
+	}
+	if p, ok := f.Precision(); ok {
+		b = append(b, '.')
+		b = strconv.AppendInt(b, int64(p), 10)
+	}
+	return string(append(b, string(verb)...))
+}
@@ -0,0 +1,27 @@
+package nullint64
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGoString(t *testing.T) {
+	tests := []struct {
+		in   Int64
+		want string
+	}{
+		{Int64From(42), "nullint64.NewInt64(42, true)"},
+		{NewInt64(0, false), "nullint64.NewInt64(0, false)"},
+		{Int64{}, "nullint64.Int64{}"},
+		{Int64{Int64: -3, Valid: true}, "nullint64.Int64{Int64: -3, Valid: true}"},
+		{Int64{Int64: 7}, "nullint64.Int64{Int64: 7}"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.GoString(); got != tt.want {
+			t.Errorf("GoString(%v) = %s, want %s", tt.in, got, tt.want)
+		}
+		if got := fmt.Sprintf("%#v", tt.in); got != tt.want {
+			t.Errorf("%%#v of %v = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+//go:build go1.18
+
+package generic
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+)
+
+// Compile-time checks that Null keeps implementing the interfaces it
+// advertises.
+var (
+	_ json.Marshaler           = Null[int32]{}
+	_ json.Unmarshaler         = (*Null[int32])(nil)
+	_ encoding.TextMarshaler   = Null[int32]{}
+	_ encoding.TextUnmarshaler = (*Null[int32])(nil)
+	_ sql.Scanner              = (*Null[int32])(nil)
+	_ driver.Valuer            = Null[int32]{}
+)
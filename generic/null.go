@@ -0,0 +1,201 @@
+//go:build go1.18
+
+// Package generic provides Null, a nullable container for any integer,
+// float, string or bool type, with the same Valid and Set tri-state as
+// nullint64.Int64. It suits widths and named types nullint64 has no
+// dedicated type for, such as int32 columns or int-based enums.
+//
+// A decoded zero value is always valid; nullint64.LegacyZeroIsNull does not
+// apply to Null.
+package generic
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/ccakes/nullint64/internal/convert"
+)
+
+// Primitive is the set of types a Null can hold.
+type Primitive interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~string | ~bool
+}
+
+// Null is a nullable T that records whether it was explicitly set.
+type Null[T Primitive] struct {
+	V     T
+	Valid bool
+	Set   bool
+}
+
+// New creates a new Null.
+func New[T Primitive](v T, valid bool) Null[T] {
+	return Null[T]{V: v, Valid: valid, Set: true}
+}
+
+// From creates a new Null that will always be valid.
+func From[T Primitive](v T) Null[T] {
+	return New(v, true)
+}
+
+// FromPtr creates a new Null that will be null if v is nil.
+func FromPtr[T Primitive](v *T) Null[T] {
+	if v == nil {
+		var zero T
+		return New(zero, false)
+	}
+	return New(*v, true)
+}
+
+// IsValid returns true if this carries an explicit value and is not null.
+func (n Null[T]) IsValid() bool {
+	return n.Set && n.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive).
+func (n Null[T]) IsSet() bool {
+	return n.Set
+}
+
+// Ptr returns a pointer to this Null's value, or nil if it is null.
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+// SetValid changes this Null's value and also sets it to be non-null.
+func (n *Null[T]) SetValid(v T) {
+	n.V, n.Valid, n.Set = v, true, true
+}
+
+// SetNull marks this Null as null.
+func (n *Null[T]) SetNull() {
+	var zero T
+	n.V, n.Valid, n.Set = zero, false, true
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null decodes as null; any
+// other input must decode into T as encoding/json would decode it.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		n.SetNull()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		n.SetNull()
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text decodes as
+// null, except for string types, where it is the empty string.
+func (n *Null[T]) UnmarshalText(text []byte) error {
+	n.Set = true
+	rv := reflect.ValueOf(&n.V).Elem()
+	if len(text) == 0 && rv.Kind() != reflect.String {
+		n.SetNull()
+		return nil
+	}
+
+	var err error
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		if v, err = strconv.ParseInt(string(text), 10, rv.Type().Bits()); err == nil {
+			rv.SetInt(v)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		if v, err = strconv.ParseUint(string(text), 10, rv.Type().Bits()); err == nil {
+			rv.SetUint(v)
+		}
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		if v, err = strconv.ParseFloat(string(text), rv.Type().Bits()); err == nil {
+			rv.SetFloat(v)
+		}
+	case reflect.Bool:
+		var v bool
+		if v, err = strconv.ParseBool(string(text)); err == nil {
+			rv.SetBool(v)
+		}
+	case reflect.String:
+		rv.SetString(string(text))
+	}
+	if err != nil {
+		n.SetNull()
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, returning empty text if
+// this Null is null.
+func (n Null[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	rv := reflect.ValueOf(n.V)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(nil, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, rv.Bool()), nil
+	default:
+		return []byte(rv.String()), nil
+	}
+}
+
+// Scan implements the sql.Scanner interface. Driver values are converted
+// as database/sql converts them, so a value out of range for T is an
+// error rather than being truncated. A NULL scans as an explicit null,
+// with Set true, as with nullint64.Int64.
+func (n *Null[T]) Scan(value interface{}) error {
+	var zero T
+	if value == nil {
+		n.V, n.Valid, n.Set = zero, false, true
+		return nil
+	}
+	if err := convert.ConvertAssign(&n.V, value); err != nil {
+		n.V, n.Valid, n.Set = zero, false, true
+		return fmt.Errorf("generic: scanning %T into %T: %w", value, n.V, err)
+	}
+	n.Valid, n.Set = true, true
+	return nil
+}
+
+// Value implements the driver Valuer interface, converting the value to
+// the underlying driver type for its kind. Unsigned values above
+// math.MaxInt64 cannot be represented and return an error.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
+}
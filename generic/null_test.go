@@ -0,0 +1,191 @@
+//go:build go1.18
+
+package generic
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+type status int8
+
+func TestJSON(t *testing.T) {
+	var i Null[int32]
+	if err := json.Unmarshal([]byte(`-7`), &i); err != nil || i != From[int32](-7) {
+		t.Errorf("Unmarshal(-7) = %+v, %v", i, err)
+	}
+	if err := json.Unmarshal([]byte(`null`), &i); err != nil || i != New[int32](0, false) {
+		t.Errorf("Unmarshal(null) = %+v, %v", i, err)
+	}
+	i = From[int32](5)
+	if err := json.Unmarshal([]byte(`"x"`), &i); err == nil || i != New[int32](0, false) {
+		t.Errorf("Unmarshal(\"x\") = %+v, %v, want error and null", i, err)
+	}
+	if err := json.Unmarshal([]byte(`2147483648`), &i); err == nil {
+		t.Error("Unmarshal of an int32 overflow succeeded")
+	}
+
+	var s struct {
+		A Null[string]  `json:"a"`
+		B Null[float64] `json:"b"`
+		C Null[bool]    `json:"c,omitempty"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"x","b":null}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.A != From("x") || s.B != New[float64](0, false) || s.C.IsSet() {
+		t.Errorf("Unmarshal into struct = %+v", s)
+	}
+	b, err := json.Marshal(s)
+	if err != nil || string(b) != `{"a":"x","b":null,"c":null}` {
+		t.Errorf("Marshal = %s, %v", b, err)
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface {
+			MarshalText() ([]byte, error)
+		}
+		want string
+	}{
+		{"int", From(-42), "-42"},
+		{"int8", From[int8](math.MinInt8), "-128"},
+		{"uint64", From[uint64](math.MaxUint64), "18446744073709551615"},
+		{"float32", From[float32](1.5), "1.5"},
+		{"float64", From(0.1), "0.1"},
+		{"bool", From(true), "true"},
+		{"string", From("abc"), "abc"},
+		{"named", From(status(3)), "3"},
+		{"null", New(7, false), ""},
+		{"unset", Null[int]{}, ""},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.MarshalText()
+		if err != nil || string(got) != tt.want {
+			t.Errorf("%s: MarshalText = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	roundTrip(t, From(-42))
+	roundTrip(t, From[uint16](math.MaxUint16))
+	roundTrip(t, From[float32](-2.5))
+	roundTrip(t, From(false))
+	roundTrip(t, From("x y"))
+	roundTrip(t, From(status(-1)))
+	roundTrip(t, New[int64](0, false))
+}
+
+func roundTrip[T Primitive](t *testing.T, in Null[T]) {
+	t.Helper()
+	text, err := in.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText(%+v): %v", in, err)
+	}
+	var out Null[T]
+	if err := out.UnmarshalText(text); err != nil || out != in {
+		t.Errorf("round trip of %+v via %q = %+v, %v", in, text, out, err)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	var s Null[string]
+	if err := s.UnmarshalText(nil); err != nil || s != From("") {
+		t.Errorf("string UnmarshalText(empty) = %+v, %v, want valid \"\"", s, err)
+	}
+	var i Null[int]
+	if err := i.UnmarshalText(nil); err != nil || i != New(0, false) {
+		t.Errorf("int UnmarshalText(empty) = %+v, %v, want null", i, err)
+	}
+
+	overflows := []struct {
+		name string
+		do   func() error
+	}{
+		{"int8", func() error { var n Null[int8]; return n.UnmarshalText([]byte("128")) }},
+		{"named int8", func() error { var n Null[status]; return n.UnmarshalText([]byte("-129")) }},
+		{"uint8", func() error { var n Null[uint8]; return n.UnmarshalText([]byte("256")) }},
+		{"uint negative", func() error { var n Null[uint]; return n.UnmarshalText([]byte("-1")) }},
+		{"float32", func() error { var n Null[float32]; return n.UnmarshalText([]byte("1e39")) }},
+		{"bool", func() error { var n Null[bool]; return n.UnmarshalText([]byte("yes")) }},
+	}
+	for _, tt := range overflows {
+		if err := tt.do(); err == nil {
+			t.Errorf("%s: UnmarshalText succeeded, want error", tt.name)
+		}
+	}
+
+	n := From[int16](9)
+	if err := n.UnmarshalText([]byte("40000")); err == nil || n != New[int16](0, false) {
+		t.Errorf("failed UnmarshalText left %+v, %v, want error and null", n, err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	var i Null[int32]
+	if err := i.Scan(int64(-5)); err != nil || i != From[int32](-5) {
+		t.Errorf("Scan(-5) = %+v, %v", i, err)
+	}
+	if err := i.Scan(nil); err != nil || i != New[int32](0, false) {
+		t.Errorf("Scan(nil) = %+v, %v", i, err)
+	}
+	if err := i.Scan(int64(math.MaxInt32) + 1); err == nil || i != New[int32](0, false) {
+		t.Errorf("Scan(MaxInt32+1) = %+v, %v, want error and null", i, err)
+	}
+
+	var u Null[uint8]
+	if err := u.Scan(int64(-1)); err == nil {
+		t.Errorf("uint8 Scan(-1) = %+v, want error", u)
+	}
+	var f Null[float64]
+	if err := f.Scan([]byte("2.5")); err != nil || f != From(2.5) {
+		t.Errorf("float64 Scan(\"2.5\") = %+v, %v", f, err)
+	}
+	var b Null[bool]
+	if err := b.Scan(true); err != nil || b != From(true) {
+		t.Errorf("bool Scan(true) = %+v, %v", b, err)
+	}
+	var s Null[string]
+	if err := s.Scan([]byte("abc")); err != nil || s != From("abc") {
+		t.Errorf("string Scan(abc) = %+v, %v", s, err)
+	}
+	var st Null[status]
+	if err := st.Scan(int64(2)); err != nil || st != From(status(2)) {
+		t.Errorf("named Scan(2) = %+v, %v", st, err)
+	}
+	if err := st.Scan(int64(200)); err == nil {
+		t.Errorf("named Scan(200) = %+v, want error", st)
+	}
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		name string
+		in   driver.Valuer
+		want driver.Value
+	}{
+		{"int32", From[int32](-3), int64(-3)},
+		{"uint32", From[uint32](math.MaxUint32), int64(math.MaxUint32)},
+		{"float32", From[float32](0.5), float64(0.5)},
+		{"bool", From(true), true},
+		{"string", From("x"), "x"},
+		{"named", From(status(4)), int64(4)},
+		{"null", New[int32](1, false), nil},
+		{"unset", Null[string]{}, nil},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.Value()
+		if err != nil || got != tt.want {
+			t.Errorf("%s: Value = %#v, %v, want %#v", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := From[uint64](math.MaxUint64).Value(); err == nil {
+		t.Error("Value of a uint64 above MaxInt64 succeeded")
+	}
+}
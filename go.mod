@@ -1,5 +1,3 @@
 module github.com/ccakes/nullint64
 
 go 1.17
-
-require github.com/volatiletech/null/v9 v9.0.0 // indirect
@@ -0,0 +1,33 @@
+package nullint64
+
+import (
+	"fmt"
+	"io"
+)
+
+// MarshalGQL implements the graphql.Marshaler interface of gqlgen,
+// writing the value as MarshalJSON encodes it, so Int64 can be bound to a
+// custom scalar directly.
+func (i Int64) MarshalGQL(w io.Writer) {
+	w.Write(i.AppendJSON(nil))
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface of gqlgen. It
+// accepts the Go integer types gqlgen produces for literals and variables,
+// and otherwise follows ScanJSON, so nil is null. gqlgen doesn't call it
+// for arguments and input fields that are absent, which stay unset.
+func (i *Int64) UnmarshalGQL(v interface{}) error {
+	switch x := v.(type) {
+	case int:
+		i.SetValid(int64(x))
+	case int32:
+		i.SetValid(int64(x))
+	case int64:
+		i.SetValid(x)
+	default:
+		if err := i.ScanJSON(v); err != nil {
+			return fmt.Errorf("nullint64: invalid GraphQL input: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package nullint64
+
+import (
+	"hash/fnv"
+	"io"
+)
+
+// HashWrite writes a canonical encoding of this Int64 to w, for feeding
+// into a hash alongside other fields. The encoding is that of
+// MarshalBinary, which distinguishes unset, null and every value, and
+// ignores the Int64 field of a null so equal states always write equal
+// bytes. It is stable across releases.
+func (i Int64) HashWrite(w io.Writer) error {
+	b, _ := i.MarshalBinary()
+	// An unset Int64 has an empty binary encoding, which would let it
+	// vanish between neighbouring fields, so it writes a zero flags byte.
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// Hash64 returns the 64-bit FNV-1a hash of the HashWrite encoding.
+func (i Int64) Hash64() uint64 {
+	h := fnv.New64a()
+	_ = i.HashWrite(h)
+	return h.Sum64()
+}
+
+// Hash implements the Hashable interface of mitchellh/hashstructure/v2,
+// returning Hash64, so a struct containing an Int64 hashes by its state
+// rather than by its raw fields.
+func (i Int64) Hash() (uint64, error) {
+	return i.Hash64(), nil
+}
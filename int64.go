@@ -2,17 +2,124 @@ package nullint64
 
 import (
 	"bytes"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
-
-	"github.com/volatiletech/null/v9/convert"
+	"strings"
 )
 
 // NullBytes is a global byte slice of JSON null
 var NullBytes = []byte("null")
 
+// MarshalNullAsZero makes MarshalJSON encode null values as 0 instead of
+// null, for legacy consumers that can't handle a JSON null in an integer
+// field. UnmarshalJSON is not affected. It applies to the numeric types;
+// Bool, String and Time always encode null as null.
+var MarshalNullAsZero = false
+
+// MarshalNullAsEmptyString makes MarshalJSON encode null values as the
+// empty string "" instead of null. UnmarshalJSON already decodes "" as
+// null, so such values round-trip unless RejectEmptyString is also set.
+// MarshalNullAsZero takes precedence if both are set, and like it this
+// applies only to the numeric types.
+var MarshalNullAsEmptyString = false
+
+// LegacyZeroIsNull restores the original UnmarshalJSON behavior of
+// decoding 0, whether a bare number or a quoted string, as null rather than
+// as a valid zero. It exists for callers that depend on that behavior and
+// will be removed in a future major version. It affects the other integer
+// types in this package, which decode through the same rules.
+var LegacyZeroIsNull = false
+
+// OmitUnsetOnly makes IsZero report true only for values that were never
+// set, rather than for every null, so the omitzero option of encoding/json
+// drops absent fields while explicit nulls are still encoded as null, as
+// PATCH payloads need. It applies to every type in this package.
+var OmitUnsetOnly = false
+
+// TextBase is the base, from 2 to 36, used for the string forms of a
+// value: MarshalText and AppendText write it, and UnmarshalText and
+// UnmarshalJSON's quoted strings parse it. JSON numbers are always
+// decimal. It defaults to 10.
+var TextBase = 10
+
+// ValueFormatter, if non-nil, renders valid values for MarshalJSON and
+// MarshalText in place of plain decimal, for outputs such as prefixed IDs.
+// MarshalJSON emits the result as a JSON string. Nulls are unaffected.
+// UnmarshalJSON and UnmarshalText only parse decimal, so values in a custom
+// format don't round-trip unless the formatter's output is decimal.
+var ValueFormatter func(int64) string
+
+// RelaxedJSON makes UnmarshalJSON accept JSON5-style integers: a leading
+// plus sign, 0x-prefixed hexadecimal and surrounding whitespace, both as
+// bare tokens and inside quoted strings. encoding/json validates its input
+// before calling UnmarshalJSON, so relaxed bare tokens are only reachable
+// when UnmarshalJSON is called directly or by a lenient decoder.
+var RelaxedJSON = false
+
+// UnwrapJSONArrays makes UnmarshalJSON accept a scalar wrapped in a
+// single-element array, so [42] decodes as 42 and [] as null. Arrays with
+// more than one element are still an error.
+var UnwrapJSONArrays = false
+
+// RejectEmptyString makes UnmarshalJSON return an error for the empty
+// string "" instead of decoding it as null.
+var RejectEmptyString = false
+
+// ScientificStrings makes UnmarshalJSON accept quoted numbers in
+// scientific notation, such as "1e9" or "1.5e3", provided they denote an
+// integer. They are converted exactly, without rounding through float64.
+var ScientificStrings = false
+
+// StrictJSON makes UnmarshalJSON accept only JSON numbers and null,
+// rejecting quoted numbers, the empty string included, with a
+// *json.UnmarshalTypeError. Numbers that aren't an int64 are reported as a
+// *NumberError carrying ErrFractional or ErrOverflow, in place of the
+// generic error encoding/json gives.
+var StrictJSON = false
+
+// TextNullWords lists words, matched case-insensitively, that
+// UnmarshalText decodes as null in addition to empty text. It is empty by
+// default; set it to e.g. []string{"null", "none", "nil"} for text formats
+// that spell out absence.
+var TextNullWords []string
+
+// TextNullToken is the text MarshalText and AppendText write for null, for
+// formats such as CSV where an empty field is ambiguous; `\N` and "NULL"
+// are common choices. Every type in this package honors it, and its
+// UnmarshalText decodes it, matched exactly, as null alongside empty text.
+// It defaults to empty.
+var TextNullToken = ""
+
+// MaxDigits caps the length of a number UnmarshalJSON or UnmarshalText
+// will attempt to parse, so oversized input is rejected before any parsing
+// work is done. A sign, and for JSON the surrounding quotes, are allowed on
+// top. Set it to 0 to disable the check.
+var MaxDigits = 20
+
+// TooLongError is returned when input exceeds MaxDigits.
+type TooLongError struct {
+	Length    int
+	MaxDigits int
+}
+
+func (e *TooLongError) Error() string {
+	return fmt.Sprintf("nullint64: input of %d bytes exceeds the %d digit limit", e.Length, e.MaxDigits)
+}
+
+// ObjectJSON makes UnmarshalJSON accept nullable integers serialized as
+// objects of the form {"value": 42, "valid": true}. valid defaults to
+// whether a non-null value is present; {"valid": false} decodes as null.
+// Objects are rejected by default.
+var ObjectJSON = false
+
+// ScanJSONBools makes ScanJSON accept true and false as 1 and 0.
+var ScanJSONBools = false
+
 // Int64 is an nullable int64.
 type Int64 struct {
 	Int64 int64
@@ -42,6 +149,27 @@ func Int64FromPtr(i *int64) Int64 {
 	return NewInt64(*i, true)
 }
 
+// Int64FromPtrOrZero creates a new Int64 that will always be valid, holding
+// 0 if i is nil. Use it where a missing pointer means zero; use
+// Int64FromPtr where it means null.
+func Int64FromPtrOrZero(i *int64) Int64 {
+	if i == nil {
+		return Int64From(0)
+	}
+	return Int64From(*i)
+}
+
+// KeyFromString parses a JSON object key, which is always a string, into a
+// valid Int64. Unlike UnmarshalText an empty key is an error rather than a
+// null, since a null map key is never meaningful.
+func KeyFromString(k string) (Int64, error) {
+	n, err := strconv.ParseInt(k, 10, 64)
+	if err != nil {
+		return Int64{}, fmt.Errorf("nullint64: invalid map key %q: %w", k, err)
+	}
+	return Int64From(n), nil
+}
+
 // IsValid returns true if this carries and explicit value and
 // is not null.
 func (i Int64) IsValid() bool {
@@ -53,72 +181,653 @@ func (i Int64) IsSet() bool {
 	return i.Set
 }
 
+// IsAmbiguousZero returns true if this Int64 is set, null and holds 0. This
+// is the state UnmarshalJSON produced for a JSON 0 before zeros decoded as
+// valid, and still produces with LegacyZeroIsNull, so it flags data that
+// may have been a zero rather than a null. Explicit nulls
+// carry the same state, so it is a diagnostic for auditing, not a proof.
+func (i Int64) IsAmbiguousZero() bool {
+	return i.Set && !i.Valid && i.Int64 == 0
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
+//
+// encoding/json only calls UnmarshalJSON for fields present in the input, so
+// after decoding into a fresh struct Set reports whether the field was there:
+// null and "" both decode to Set=true, Valid=false, while an absent field is
+// left untouched with Set=false. Decoder options such as
+// DisallowUnknownFields don't change this. Absent fields keep whatever state
+// they had before, so reset reused structs before decoding into them.
 func (i *Int64) UnmarshalJSON(data []byte) error {
 	i.Set = true
+	if RelaxedJSON {
+		data = bytes.TrimSpace(data)
+	}
 	if bytes.Equal(data, NullBytes) {
 		i.Valid = false
 		i.Int64 = 0
 		return nil
 	}
+	if len(data) > 0 && data[0] != '[' && data[0] != '{' {
+		// Scalar token; allow for a sign and the surrounding quotes.
+		if err := checkLength(data, 3); err != nil {
+			i.Valid = false
+			i.Int64 = 0
+			return err
+		}
+	}
+	if n, ok := parseJSONDecimal(data); ok {
+		i.Int64 = n
+		return i.finishJSON(nil)
+	}
 
 	var (
 		v   interface{}
 		err error
 	)
-	if err := json.Unmarshal(data, &v); err != nil {
+	if RelaxedJSON && len(data) > 0 && !json.Valid(data) {
+		// Not strict JSON; give the raw token the same relaxed parsing
+		// as a quoted number.
+		v = string(data)
+	} else if err := json.Unmarshal(data, &v); err != nil {
+		i.Int64, i.Valid = 0, false
+		if n := numberPrefix(data); n > 0 && n < len(data) {
+			return &ParseError{Input: string(data), Offset: n, Err: err}
+		}
 		return err
 	}
+	if _, ok := v.([]interface{}); ok && UnwrapJSONArrays {
+		return i.unmarshalWrapped(data)
+	}
+	if _, ok := v.(map[string]interface{}); ok && ObjectJSON {
+		return i.unmarshalObject(data)
+	}
 
 	switch x := v.(type) {
 	case float64:
+		if StrictJSON {
+			i.Int64, err = strictNumber(data)
+			break
+		}
 		// Unmarshal again direct to int64 to avoid intermediate float64
 		err = json.Unmarshal(data, &i.Int64)
 	case string:
-		str := string(x)
-		if len(str) == 0 {
-			i.Valid = false
-			return nil
+		if StrictJSON && len(data) > 0 && data[0] == '"' {
+			err = &json.UnmarshalTypeError{Value: "string", Type: int64Type}
+			break
 		}
-		i.Int64, err = strconv.ParseInt(str, 10, 64)
+		return i.unmarshalJSONString(x)
 	case nil:
 		i.Valid = false
+		i.Int64 = 0
 		return nil
 	default:
-		err = fmt.Errorf("json: cannot unmarshal %T into Go value of type nullint64.Int64", v)
+		err = fmt.Errorf("nullint64: cannot unmarshal JSON %s into Int64", jsonKind(data))
+	}
+
+	return i.finishJSON(err)
+}
+
+// parseJSONDecimal is the fast path of UnmarshalJSON, parsing a plain
+// decimal number, bare or quoted, without decoding through an interface{}.
+// ok is false for anything else, including escaped strings, options that
+// change parsing and invalid input, which take the general path.
+func parseJSONDecimal(data []byte) (n int64, ok bool) {
+	if RelaxedJSON || len(data) == 0 {
+		return 0, false
+	}
+	switch c := data[0]; {
+	case c == '"':
+		if StrictJSON || TextBase != 10 || len(data) < 2 || data[len(data)-1] != '"' {
+			return 0, false
+		}
+		inner := data[1 : len(data)-1]
+		if bytes.IndexAny(inner, "\"\\") >= 0 {
+			return 0, false
+		}
+		return parseDecimal(inner)
+	case c == '-' || ('0' <= c && c <= '9'):
+		// JSON numbers have no leading zeros.
+		digits := bytes.TrimPrefix(data, []byte("-"))
+		if len(digits) > 1 && digits[0] == '0' {
+			return 0, false
+		}
+		return parseDecimal(data)
+	default:
+		return 0, false
 	}
+}
 
-	i.Valid = (err == nil) && (i.Int64 != 0)
+// strictNumber parses the JSON number data for StrictJSON, classifying
+// numbers that aren't an int64 as ErrFractional or ErrOverflow. Integral
+// numbers written with a fraction or exponent, such as 1.0 or 1e3, are
+// rejected as they are outside strict mode.
+func strictNumber(data []byte) (int64, error) {
+	num := string(bytes.TrimSpace(data))
+	_, err := parseScientific(num)
+	switch {
+	case numError(err) == strconv.ErrRange:
+		return 0, &NumberError{Input: num, Err: ErrOverflow}
+	case err != nil:
+		return 0, &NumberError{Input: num, Err: ErrFractional}
+	}
+	var n int64
+	err = json.Unmarshal(data, &n)
+	return n, err
+}
+
+// unmarshalJSONString decodes the contents of a JSON string.
+func (i *Int64) unmarshalJSONString(str string) error {
+	if RelaxedJSON {
+		str = strings.TrimSpace(str)
+	}
+	if len(str) == 0 {
+		i.Valid = false
+		i.Int64 = 0
+		if RejectEmptyString {
+			return errors.New("nullint64: empty string is not a valid Int64")
+		}
+		return nil
+	}
+	var err error
+	if RelaxedJSON {
+		i.Int64, err = parseRelaxed(str)
+	} else {
+		i.Int64, err = strconv.ParseInt(str, TextBase, 64)
+	}
+	if err != nil && ScientificStrings && strings.ContainsAny(str, "eE") {
+		i.Int64, err = parseScientific(str)
+	}
+	if !RelaxedJSON && errors.Is(err, strconv.ErrSyntax) {
+		err = &ParseError{Input: str, Offset: invalidOffset([]byte(str)), Err: err}
+	}
+	return i.finishJSON(err)
+}
+
+// numberPrefix returns the length of the run of JSON number characters at
+// the start of data.
+func numberPrefix(data []byte) int {
+	n := 0
+	for n < len(data) && strings.IndexByte("+-0123456789.eE", data[n]) >= 0 {
+		n++
+	}
+	return n
+}
+
+// finishJSON sets Valid once a JSON value has been decoded into i.Int64
+// with the given error.
+func (i *Int64) finishJSON(err error) error {
+	i.Valid = jsonValid(err, i.Int64 == 0)
+	if !i.Valid {
+		i.Int64 = 0
+	}
 	return err
 }
 
+// jsonValid reports whether a decoded JSON value is valid, given the
+// decoding error and whether the value decoded as zero.
+func jsonValid(err error, zero bool) bool {
+	return err == nil && !(zero && LegacyZeroIsNull)
+}
+
+// ScanJSON sets this Int64 from v, a value already decoded by encoding/json
+// into an interface{}, following the same rules as UnmarshalJSON. float64
+// values must be integral and within range, strings and json.Number are
+// parsed, nil is null and bools are accepted as 1 and 0 if ScanJSONBools
+// is set.
+func (i *Int64) ScanJSON(v interface{}) error {
+	i.Set = true
+	var err error
+	switch x := v.(type) {
+	case nil:
+		i.Valid = false
+		i.Int64 = 0
+		return nil
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			err = fmt.Errorf("nullint64: cannot scan %v into Int64", x)
+			break
+		}
+		if x != math.Trunc(x) || x < math.MinInt64 || x >= -math.MinInt64 {
+			err = fmt.Errorf("nullint64: cannot scan JSON number %v into Int64", x)
+			break
+		}
+		i.Int64 = int64(x)
+	case json.Number:
+		i.Int64, err = x.Int64()
+	case string:
+		return i.unmarshalJSONString(x)
+	case bool:
+		if !ScanJSONBools {
+			err = fmt.Errorf("nullint64: cannot scan JSON bool into Int64")
+			break
+		}
+		i.Int64 = 0
+		if x {
+			i.Int64 = 1
+		}
+	default:
+		err = fmt.Errorf("nullint64: cannot scan %T into Int64", v)
+	}
+	return i.finishJSON(err)
+}
+
+// unmarshalWrapped decodes a scalar wrapped in a JSON array, for
+// UnwrapJSONArrays.
+func (i *Int64) unmarshalWrapped(data []byte) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	i.Valid = false
+	i.Int64 = 0
+	switch {
+	case len(elems) == 0:
+		return nil
+	case len(elems) > 1:
+		return fmt.Errorf("nullint64: cannot unwrap JSON array of %d elements into Int64", len(elems))
+	case jsonKind(elems[0]) == "array":
+		return fmt.Errorf("nullint64: cannot unwrap nested JSON array into Int64")
+	}
+	return i.UnmarshalJSON(elems[0])
+}
+
+// unmarshalObject decodes the {"value":n,"valid":b} form, for ObjectJSON.
+func (i *Int64) unmarshalObject(data []byte) error {
+	var obj struct {
+		Value json.RawMessage `json:"value"`
+		Valid *bool           `json:"valid"`
+	}
+	i.Valid = false
+	i.Int64 = 0
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	hasValue := len(obj.Value) > 0 && !bytes.Equal(obj.Value, NullBytes)
+	valid := hasValue
+	if obj.Valid != nil {
+		valid = *obj.Valid
+	}
+	if !valid {
+		return nil
+	}
+	if !hasValue {
+		return errors.New("nullint64: JSON object is valid but has no value")
+	}
+
+	var n int64
+	if err := json.Unmarshal(obj.Value, &n); err != nil {
+		return err
+	}
+	i.Int64, i.Valid = n, true
+	return nil
+}
+
+// parseRelaxed parses s as a decimal or 0x-prefixed hexadecimal integer
+// with an optional leading sign.
+func parseRelaxed(s string) (int64, error) {
+	num, sign := s, ""
+	if len(num) > 0 && (num[0] == '+' || num[0] == '-') {
+		if num[0] == '-' {
+			sign = "-"
+		}
+		num = num[1:]
+	}
+	base := 10
+	if len(num) > 2 && num[0] == '0' && (num[1] == 'x' || num[1] == 'X') {
+		base = 16
+		num = num[2:]
+	}
+	if len(num) == 0 || num[0] == '+' || num[0] == '-' {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: s, Err: strconv.ErrSyntax}
+	}
+	n, err := strconv.ParseInt(sign+num, base, 64)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: s, Err: err.(*strconv.NumError).Err}
+	}
+	return n, nil
+}
+
+// parseScientific parses s, a decimal in scientific notation such as 1e9
+// or 1.5e3, exactly. Values with a fractional part are an error.
+func parseScientific(s string) (int64, error) {
+	mant, expStr := s, "0"
+	if k := strings.IndexAny(s, "eE"); k >= 0 {
+		mant, expStr = s[:k], s[k+1:]
+	}
+	exp, err := strconv.Atoi(expStr)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: s, Err: strconv.ErrSyntax}
+	}
+
+	sign := ""
+	if len(mant) > 0 && (mant[0] == '+' || mant[0] == '-') {
+		if mant[0] == '-' {
+			sign = "-"
+		}
+		mant = mant[1:]
+	}
+	intPart, frac := mant, ""
+	if k := strings.IndexByte(mant, '.'); k >= 0 {
+		intPart, frac = mant[:k], mant[k+1:]
+	}
+	digits := intPart + frac
+	if len(digits) == 0 || strings.Trim(digits, "0123456789") != "" {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: s, Err: strconv.ErrSyntax}
+	}
+
+	// The value is digits * 10^exp; reduce it to an integer string without
+	// going through float64, which would lose precision.
+	exp -= len(frac)
+	digits = strings.TrimLeft(digits, "0")
+	if len(digits) == 0 {
+		return 0, nil
+	}
+	for exp < 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		exp++
+	}
+	if exp < 0 {
+		return 0, fmt.Errorf("nullint64: %q is not an integer", s)
+	}
+	if len(digits)+exp > 19 {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: s, Err: strconv.ErrRange}
+	}
+	n, err := strconv.ParseInt(sign+digits+strings.Repeat("0", exp), 10, 64)
+	if err != nil {
+		return 0, &strconv.NumError{Func: "ParseInt", Num: s, Err: err.(*strconv.NumError).Err}
+	}
+	return n, nil
+}
+
+// jsonKind names the type of the JSON value in data from its first
+// significant byte, for use in error messages.
+func jsonKind(data []byte) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "value"
+	}
+	switch data[0] {
+	case '[':
+		return "array"
+	case '{':
+		return "object"
+	case 't', 'f':
+		return "bool"
+	case '"':
+		return "string"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler.
 func (i *Int64) UnmarshalText(text []byte) error {
 	i.Set = true
-	if len(text) == 0 {
+	if len(text) == 0 || isNullWord(text) {
 		i.Valid = false
+		i.Int64 = 0
 		return nil
 	}
+	if err := checkLength(text, 1); err != nil {
+		i.Valid = false
+		i.Int64 = 0
+		return err
+	}
+	if TextBase == 10 {
+		if n, ok := parseDecimal(text); ok {
+			i.Int64, i.Valid = n, true
+			return nil
+		}
+	}
 	var err error
-	i.Int64, err = strconv.ParseInt(string(text), 10, 64)
+	i.Int64, err = strconv.ParseInt(string(text), TextBase, 64)
 	i.Valid = err == nil
+	if !i.Valid {
+		i.Int64 = 0
+		if errors.Is(err, strconv.ErrSyntax) {
+			err = &ParseError{Input: string(text), Offset: invalidOffset(text), Err: err}
+		}
+	}
 	return err
 }
 
+// parseDecimal parses b as a decimal int64 without the allocation of
+// converting it to a string. ok is false for anything outside the common
+// case, including syntax errors and overflow, so the caller can fall back
+// to strconv for the full error.
+func parseDecimal(b []byte) (n int64, ok bool) {
+	neg := false
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		neg = b[0] == '-'
+		b = b[1:]
+	}
+	// 19 digits always fit in a uint64; longer input is left to strconv.
+	if len(b) == 0 || len(b) > 19 {
+		return 0, false
+	}
+	var u uint64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		u = u*10 + uint64(c-'0')
+	}
+	if neg {
+		if u > 1<<63 {
+			return 0, false
+		}
+		return -int64(u), true
+	}
+	if u > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(u), true
+}
+
+// ParseError reports the position of invalid input to UnmarshalText or
+// UnmarshalJSON. Both reject trailing data after a complete number, such
+// as 42abc, with Offset pointing at the first trailing byte; for JSON,
+// whitespace around the token is allowed as the JSON grammar permits,
+// while text must contain the number alone. For a quoted JSON number the
+// Input and Offset refer to the string's contents.
+type ParseError struct {
+	Input string
+	// Offset is the byte offset of the first invalid character, or
+	// len(Input) if the input ended before a digit was seen.
+	Offset int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	if e.Offset >= len(e.Input) {
+		return fmt.Sprintf("nullint64: unexpected end of input in %q", e.Input)
+	}
+	return fmt.Sprintf("nullint64: invalid character %q at offset %d in %q", e.Input[e.Offset], e.Offset, e.Input)
+}
+
+// Unwrap returns the underlying *strconv.NumError, or for a bare JSON
+// token the *json.SyntaxError.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrFractional reports a number with a fractional part where an integer
+// is required.
+var ErrFractional = errors.New("value has a fractional part")
+
+// NumberError is returned by UnmarshalJSON, when StrictJSON is set, for a
+// JSON number that isn't an int64. Err is ErrFractional or ErrOverflow.
+type NumberError struct {
+	Input string
+	Err   error
+}
+
+func (e *NumberError) Error() string {
+	if e.Err == ErrOverflow {
+		return fmt.Sprintf("nullint64: JSON number %s overflows int64", e.Input)
+	}
+	return fmt.Sprintf("nullint64: JSON number %s: %v", e.Input, e.Err)
+}
+
+// Unwrap returns ErrFractional or ErrOverflow.
+func (e *NumberError) Unwrap() error {
+	return e.Err
+}
+
+// invalidOffset returns the offset of the first byte in text that can't be
+// part of an integer in TextBase, or len(text) if there is none.
+func invalidOffset(text []byte) int {
+	k := 0
+	if len(text) > 0 && (text[0] == '+' || text[0] == '-') {
+		k++
+	}
+	for ; k < len(text); k++ {
+		if digitValue(text[k]) >= TextBase {
+			return k
+		}
+	}
+	return len(text)
+}
+
+// digitValue returns the value of c as a digit in bases up to 36, or 36 if
+// it isn't one.
+func digitValue(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'z':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'Z':
+		return int(c-'A') + 10
+	default:
+		return 36
+	}
+}
+
+// checkLength returns a *TooLongError if input is longer than MaxDigits
+// plus extra bytes of sign, quoting or similar decoration.
+func checkLength(input []byte, extra int) error {
+	// Bases below 10 need more digits than decimal for the same value.
+	if TextBase < 10 {
+		n := 0
+		for v := uint64(1 << 63); v > 0; v /= uint64(TextBase) {
+			n++
+		}
+		extra += n - 19
+	}
+	if MaxDigits > 0 && len(input) > MaxDigits+extra {
+		return &TooLongError{Length: len(input), MaxDigits: MaxDigits}
+	}
+	return nil
+}
+
+// isNullWord reports whether text is TextNullToken or matches one of
+// TextNullWords.
+func isNullWord(text []byte) bool {
+	if TextNullToken != "" && string(text) == TextNullToken {
+		return true
+	}
+	for _, w := range TextNullWords {
+		if strings.EqualFold(string(text), w) {
+			return true
+		}
+	}
+	return false
+}
+
 // MarshalJSON implements json.Marshaler.
 func (i Int64) MarshalJSON() ([]byte, error) {
+	// Always return a fresh slice; handing out NullBytes itself would let
+	// callers mutate shared state.
+	return i.AppendJSON(nil), nil
+}
+
+// Canonical returns the canonical JSON encoding of this Int64: null, or the
+// minimal decimal form of the value. Unlike MarshalJSON its output never
+// depends on package-level options, so it is safe to use when signing or
+// hashing payloads.
+func (i Int64) Canonical() []byte {
+	if !i.Valid {
+		return []byte("null")
+	}
+	return strconv.AppendInt(nil, i.Int64, 10)
+}
+
+// AppendJSON appends the JSON encoding of this Int64, as MarshalJSON
+// produces it, to b and returns the extended buffer. Unless ValueFormatter
+// is set it allocates only if b lacks capacity, so reusing a buffer
+// avoids MarshalJSON's allocation per call.
+func (i Int64) AppendJSON(b []byte) []byte {
 	if !i.Valid {
-		return NullBytes, nil
+		return appendNullJSON(b)
+	}
+	if ValueFormatter != nil {
+		// Marshaling a string can't fail.
+		q, _ := json.Marshal(ValueFormatter(i.Int64))
+		return append(b, q...)
+	}
+	return strconv.AppendInt(b, i.Int64, 10)
+}
+
+// appendNullJSON appends the JSON encoding of a null, as chosen by
+// MarshalNullAsZero and MarshalNullAsEmptyString, to b.
+func appendNullJSON(b []byte) []byte {
+	if MarshalNullAsZero {
+		return append(b, '0')
+	}
+	if MarshalNullAsEmptyString {
+		return append(b, '"', '"')
+	}
+	return append(b, NullBytes...)
+}
+
+// JSONLen returns the number of bytes MarshalJSON would produce for this
+// Int64, without building the encoding, for pre-sizing buffers.
+func (i Int64) JSONLen() int {
+	if !i.Valid {
+		switch {
+		case MarshalNullAsZero:
+			return 1
+		case MarshalNullAsEmptyString:
+			return 2
+		}
+		return len(NullBytes)
+	}
+	if ValueFormatter != nil {
+		return len(i.AppendJSON(nil))
+	}
+
+	n, u := 1, uint64(i.Int64)
+	if i.Int64 < 0 {
+		n, u = 2, -u
+	}
+	for ; u >= 10; u /= 10 {
+		n++
 	}
-	return []byte(strconv.FormatInt(i.Int64, 10)), nil
+	return n
 }
 
 // MarshalText implements encoding.TextMarshaler.
 func (i Int64) MarshalText() ([]byte, error) {
+	return i.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, appending the decimal form
+// of the value to b, or TextNullToken if this Int64 is null. As with
+// AppendJSON it allocates only if b lacks capacity, unless ValueFormatter
+// is set, and the error is always nil.
+func (i Int64) AppendText(b []byte) ([]byte, error) {
 	if !i.Valid {
-		return []byte{}, nil
+		return append(b, TextNullToken...), nil
 	}
-	return []byte(strconv.FormatInt(i.Int64, 10)), nil
+	if ValueFormatter != nil {
+		return append(b, ValueFormatter(i.Int64)...), nil
+	}
+	return strconv.AppendInt(b, i.Int64, TextBase), nil
 }
 
 // SetValid changes this Int64's value and also sets it to be non-null.
@@ -128,6 +837,25 @@ func (i *Int64) SetValid(n int64) {
 	i.Set = true
 }
 
+// SetFromString parses s into this Int64 as UnmarshalText does: an empty
+// string or TextNullToken sets it to null, otherwise s must be an integer
+// in TextBase. Set is true afterwards even if parsing fails.
+func (i *Int64) SetFromString(s string) error {
+	return i.UnmarshalText([]byte(s))
+}
+
+// SetNull sets this Int64 to an explicit null.
+func (i *Int64) SetNull() {
+	i.Int64 = 0
+	i.Valid = false
+	i.Set = true
+}
+
+// Swap exchanges the full state of this Int64 and other.
+func (i *Int64) Swap(other *Int64) {
+	*i, *other = *other, *i
+}
+
 // Ptr returns a pointer to this Int64's value, or a nil pointer if this Int64 is null.
 func (i Int64) Ptr() *int64 {
 	if !i.Valid {
@@ -136,25 +864,88 @@ func (i Int64) Ptr() *int64 {
 	return &i.Int64
 }
 
-// IsZero returns true for invalid Int64's, for future omitempty support (Go 1.4?)
+// IsZero returns true for invalid Int64's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
 func (i Int64) IsZero() bool {
-	return !i.Valid
+	return isZero(i.Valid, i.Set)
+}
+
+// isZero implements IsZero for every type in the package.
+func isZero(valid, set bool) bool {
+	if OmitUnsetOnly {
+		return !set
+	}
+	return !valid
 }
 
-// Scan implements the Scanner interface.
+// IsZeroOrNull returns true for invalid Int64's and for a valid 0. Unlike
+// IsZero it doesn't preserve the distinction between null and zero, for
+// outputs that should omit both.
+func (i Int64) IsZeroOrNull() bool {
+	return !i.Valid || i.Int64 == 0
+}
+
+// Scan implements the Scanner interface. It accepts every integer and float
+// type, with floats required to be integral and every value to fit in an
+// int64, and decimal or 0x-prefixed hexadecimal text as a string or bytes,
+// ignoring surrounding whitespace. A time.Time is scanned as its Unix time
+// in seconds; use a ScanConfig with TimeUnit for other units.
+//
+// Every scan sets Set, a NULL included, which scans as an explicit null:
+// after a read, Set reports that the column was received and Valid that it
+// was not NULL, while an Int64 that was never scanned stays unset. The
+// other types in this package scan the same way.
 func (i *Int64) Scan(value interface{}) error {
-	if value == nil {
-		i.Int64, i.Valid, i.Set = 0, false, false
-		return nil
+	return ScanConfig{}.scan(i, value)
+}
+
+// SafeScan is like Scan, but on any error resets this Int64 to the zero
+// Int64{} so a reused value is never left partially updated.
+func (i *Int64) SafeScan(value interface{}) error {
+	if err := i.Scan(value); err != nil {
+		*i = Int64{}
+		return err
 	}
-	i.Valid, i.Set = true, true
-	return convert.ConvertAssign(&i.Int64, value)
+	return nil
 }
 
 // Value implements the driver Valuer interface.
+//
+// Value and Scan round-trip through a database column: valid values,
+// including 0, come back valid and null comes back null, both with Set
+// true. An unset Int64 is written as NULL too and so comes back as an
+// explicit null; compare round-tripped values with WeakEqual if the
+// originals may be unset.
 func (i Int64) Value() (driver.Value, error) {
 	if !i.Valid {
 		return nil, nil
 	}
 	return i.Int64, nil
 }
+
+// NamedValue returns a sql.NamedArg binding this Int64 to the named
+// parameter name: its value when valid, nil when null.
+func (i Int64) NamedValue(name string) sql.NamedArg {
+	if !i.Valid {
+		return sql.Named(name, nil)
+	}
+	return sql.Named(name, i.Int64)
+}
+
+// DefaultValue is the marker returned by ValueOrDefault for an Int64 that
+// was never set. It is not a valid driver.Value; query builders should check
+// for it and emit the SQL DEFAULT keyword in place of a bound parameter.
+type DefaultValue struct{}
+
+// Default is the DefaultValue marker returned by ValueOrDefault.
+var Default = DefaultValue{}
+
+// ValueOrDefault is like Value, but distinguishes an unset Int64 from one
+// explicitly set to null. It returns Default when !Set, nil when set to null
+// and the int64 otherwise.
+func (i Int64) ValueOrDefault() (driver.Value, error) {
+	if !i.Set {
+		return Default, nil
+	}
+	return i.Value()
+}
@@ -0,0 +1,982 @@
+package nullint64
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestValueOrDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Int64
+		want driver.Value
+	}{
+		{"unset", Int64{}, Default},
+		{"null", NewInt64(0, false), nil},
+		{"zero", Int64From(0), int64(0)},
+		{"value", Int64From(9), int64(9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.in.ValueOrDefault()
+			if err != nil || got != tt.want {
+				t.Errorf("ValueOrDefault() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONNonScalar(t *testing.T) {
+	tests := []struct {
+		data    string
+		wantErr string
+	}{
+		{`[1]`, "nullint64: cannot unmarshal JSON array into Int64"},
+		{`[[[[[[[[1]]]]]]]]`, "nullint64: cannot unmarshal JSON array into Int64"},
+		{` {"a": [1]}`, "nullint64: cannot unmarshal JSON object into Int64"},
+		{`true`, "nullint64: cannot unmarshal JSON bool into Int64"},
+	}
+	for _, tt := range tests {
+		i := Int64From(5)
+		err := i.UnmarshalJSON([]byte(tt.data))
+		if err == nil || err.Error() != tt.wantErr {
+			t.Errorf("UnmarshalJSON(%s) error = %v, want %q", tt.data, err, tt.wantErr)
+		}
+		if i.Valid || !i.Set || i.Int64 != 0 {
+			t.Errorf("UnmarshalJSON(%s) left %+v, want explicit null", tt.data, i)
+		}
+	}
+}
+
+func TestUnmarshalJSONDeepNesting(t *testing.T) {
+	data := []byte(strings.Repeat("[", 10000) + strings.Repeat("]", 10000))
+	var i Int64
+	if err := i.UnmarshalJSON(data); err == nil {
+		t.Error("UnmarshalJSON of deeply nested arrays succeeded")
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	tests := []struct {
+		in   Int64
+		want string
+	}{
+		{Int64From(42), "prefix:42"},
+		{Int64From(-1), "prefix:-1"},
+		{NewInt64(0, false), "prefix:"},
+		{Int64{}, "prefix:"},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.AppendText([]byte("prefix:"))
+		if err != nil || string(got) != tt.want {
+			t.Errorf("AppendText(%+v) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+		text, _ := tt.in.MarshalText()
+		if string(text) != tt.want[len("prefix:"):] {
+			t.Errorf("MarshalText(%+v) = %q, AppendText disagrees", tt.in, text)
+		}
+	}
+}
+
+func TestAppendTextAllocs(t *testing.T) {
+	buf := make([]byte, 0, 32)
+	v := Int64From(1234567890)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = v.AppendText(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendText allocated %v times per call, want 0", allocs)
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	buf := make([]byte, 0, 32)
+	v := Int64From(1234567890)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		buf, _ = v.AppendText(buf[:0])
+	}
+}
+
+func TestAppendJSON(t *testing.T) {
+	for _, in := range []Int64{Int64From(42), Int64From(-1), NewInt64(0, false), {}} {
+		want, _ := in.MarshalJSON()
+		if got := in.AppendJSON([]byte("prefix:")); string(got) != "prefix:"+string(want) {
+			t.Errorf("AppendJSON(%+v) = %q, want prefix:%s", in, got, want)
+		}
+	}
+}
+
+func TestAppendJSONAllocs(t *testing.T) {
+	buf := make([]byte, 0, 32)
+	for _, v := range []Int64{Int64From(1234567890), NewInt64(0, false)} {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = v.AppendJSON(buf[:0])
+		})
+		if allocs != 0 {
+			t.Errorf("AppendJSON(%+v) allocated %v times per call, want 0", v, allocs)
+		}
+	}
+}
+
+func BenchmarkAppendJSON(b *testing.B) {
+	buf := make([]byte, 0, 32)
+	v := Int64From(1234567890)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		buf = v.AppendJSON(buf[:0])
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	v := Int64From(1234567890)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if _, err := v.MarshalJSON(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalText(b *testing.B) {
+	v := Int64From(1234567890)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if _, err := v.MarshalText(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestFailedDecodeLeavesCanonicalNull(t *testing.T) {
+	null := NewInt64(0, false)
+	tests := []struct {
+		name   string
+		decode func(*Int64) error
+	}{
+		{"UnmarshalJSON", func(i *Int64) error { return i.UnmarshalJSON([]byte(`"12x"`)) }},
+		{"UnmarshalJSON invalid", func(i *Int64) error { return i.UnmarshalJSON([]byte(`abc`)) }},
+		{"UnmarshalJSON trailing data", func(i *Int64) error { return i.UnmarshalJSON([]byte(`42abc`)) }},
+		{"UnmarshalJSON overflow", func(i *Int64) error { return i.UnmarshalJSON([]byte(`99999999999999999999`)) }},
+		{"UnmarshalText", func(i *Int64) error { return i.UnmarshalText([]byte("abc")) }},
+		{"Scan", func(i *Int64) error { return i.Scan("abc") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := Int64From(77)
+			if err := tt.decode(&i); err == nil {
+				t.Fatal("decode succeeded")
+			}
+			if !reflect.DeepEqual(i, null) {
+				t.Errorf("after failed decode = %+v, want %+v", i, null)
+			}
+		})
+	}
+
+	i := Int64From(77)
+	i.SetNull()
+	if !reflect.DeepEqual(i, null) {
+		t.Errorf("SetNull() = %+v, want %+v", i, null)
+	}
+}
+
+func TestSetFromString(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Int64
+		wantErr bool
+	}{
+		{"42", Int64From(42), false},
+		{"-7", Int64From(-7), false},
+		{"", NewInt64(0, false), false},
+		{"4x", NewInt64(0, false), true},
+		{"9223372036854775808", NewInt64(0, false), true},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := i.SetFromString(tt.in)
+		if (err != nil) != tt.wantErr || i != tt.want {
+			t.Errorf("SetFromString(%q) = %+v, %v, want %+v", tt.in, i, err, tt.want)
+		}
+	}
+}
+
+func TestMarshalNullAsZero(t *testing.T) {
+	setBool(t, &MarshalNullAsZero, true)
+	tests := []struct {
+		in   Int64
+		want string
+	}{
+		{NewInt64(0, false), "0"},
+		{Int64{}, "0"},
+		{Int64From(0), "0"},
+		{Int64From(3), "3"},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.MarshalJSON()
+		if err != nil || string(got) != tt.want {
+			t.Errorf("MarshalJSON(%+v) = %s, %v, want %s", tt.in, got, err, tt.want)
+		}
+	}
+
+	// Decoding is unaffected.
+	var i Int64
+	if err := i.UnmarshalJSON([]byte("null")); err != nil || i.Valid {
+		t.Errorf("UnmarshalJSON(null) = %+v, %v", i, err)
+	}
+}
+
+func TestRelaxedJSON(t *testing.T) {
+	setBool(t, &RelaxedJSON, true)
+	tests := []struct {
+		data    string
+		want    int64
+		wantErr bool
+	}{
+		{`+5`, 5, false},
+		{`0x1F`, 31, false},
+		{`-0x10`, -16, false},
+		{` 12 `, 12, false},
+		{`"+7"`, 7, false},
+		{`" 0xff "`, 255, false},
+		{`"++1"`, 0, true},
+		{`0x`, 0, true},
+		{`"0xzz"`, 0, true},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := i.UnmarshalJSON([]byte(tt.data))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("UnmarshalJSON(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (!i.Valid || i.Int64 != tt.want) {
+			t.Errorf("UnmarshalJSON(%s) = %+v, want %d", tt.data, i, tt.want)
+		}
+	}
+}
+
+func TestRelaxedJSONOff(t *testing.T) {
+	for _, data := range []string{`+5`, `0x1F`, `" 0xff "`} {
+		var i Int64
+		if err := i.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) without RelaxedJSON = %+v, want error", data, i)
+		}
+	}
+}
+
+func TestIsAmbiguousZero(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Int64
+		want bool
+	}{
+		{"unset", Int64{}, false},
+		{"null", NewInt64(0, false), true},
+		{"zero", Int64From(0), false},
+		{"value", Int64From(1), false},
+	}
+	for _, tt := range tests {
+		if got := tt.in.IsAmbiguousZero(); got != tt.want {
+			t.Errorf("%s: IsAmbiguousZero() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	setBool(t, &LegacyZeroIsNull, true)
+	var i Int64
+	if err := i.UnmarshalJSON([]byte("0")); err != nil || !i.IsAmbiguousZero() {
+		t.Errorf("UnmarshalJSON(0) with LegacyZeroIsNull = %+v, %v, want ambiguous zero", i, err)
+	}
+}
+
+func TestUnmarshalJSONPresence(t *testing.T) {
+	type payload struct {
+		A Int64 `json:"a"`
+		B Int64 `json:"b"`
+		C Int64 `json:"c"`
+		D Int64 `json:"d"`
+	}
+	for _, disallow := range []bool{false, true} {
+		var p payload
+		dec := json.NewDecoder(strings.NewReader(`{"a": 1, "b": null, "c": ""}`))
+		if disallow {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(&p); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		want := payload{A: Int64From(1), B: NewInt64(0, false), C: NewInt64(0, false)}
+		if p != want {
+			t.Errorf("DisallowUnknownFields %v: decoded %+v, want %+v", disallow, p, want)
+		}
+	}
+}
+
+func TestKeyFromString(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Int64
+		wantErr bool
+	}{
+		{"12", Int64From(12), false},
+		{"-3", Int64From(-3), false},
+		{"", Int64{}, true},
+		{"1.0", Int64{}, true},
+	}
+	for _, tt := range tests {
+		got, err := KeyFromString(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("KeyFromString(%q) = %+v, %v, want %+v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	var m map[Int64]string
+	if err := json.Unmarshal([]byte(`{"1": "a", "-2": "b"}`), &m); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(m) != 2 || m[Int64From(1)] != "a" || m[Int64From(-2)] != "b" {
+		t.Errorf("decoded %v", m)
+	}
+	b, err := json.Marshal(map[Int64]int{Int64From(7): 1})
+	if err != nil || string(b) != `{"7":1}` {
+		t.Errorf("Marshal = %s, %v", b, err)
+	}
+}
+
+func TestUnwrapJSONArrays(t *testing.T) {
+	setBool(t, &UnwrapJSONArrays, true)
+	tests := []struct {
+		data    string
+		want    Int64
+		wantErr bool
+	}{
+		{`[42]`, Int64From(42), false},
+		{`["7"]`, Int64From(7), false},
+		{`[null]`, NewInt64(0, false), false},
+		{`[]`, NewInt64(0, false), false},
+		{`[1, 2]`, NewInt64(0, false), true},
+		{`[[1]]`, NewInt64(0, false), true},
+		{`5`, Int64From(5), false},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := i.UnmarshalJSON([]byte(tt.data))
+		if (err != nil) != tt.wantErr || i != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %+v, %v, want %+v", tt.data, i, err, tt.want)
+		}
+	}
+}
+
+func TestPtrConstructors(t *testing.T) {
+	n := int64(8)
+	tests := []struct {
+		name string
+		got  Int64
+		want Int64
+	}{
+		{"FromPtr value", Int64FromPtr(&n), Int64From(8)},
+		{"FromPtr nil", Int64FromPtr(nil), NewInt64(0, false)},
+		{"FromPtrOrZero value", Int64FromPtrOrZero(&n), Int64From(8)},
+		{"FromPtrOrZero nil", Int64FromPtrOrZero(nil), Int64From(0)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	setBool(t, &MarshalNullAsZero, true)
+	oldFormatter := ValueFormatter
+	ValueFormatter = func(int64) string { return "formatted" }
+	t.Cleanup(func() { ValueFormatter = oldFormatter })
+
+	tests := []struct {
+		in   Int64
+		want string
+	}{
+		{Int64From(42), "42"},
+		{Int64From(-9), "-9"},
+		{Int64From(0), "0"},
+		{NewInt64(0, false), "null"},
+		{Int64{}, "null"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.Canonical(); string(got) != tt.want {
+			t.Errorf("Canonical(%+v) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsZeroOrNull(t *testing.T) {
+	tests := []struct {
+		in         Int64
+		isZero     bool
+		zeroOrNull bool
+	}{
+		{Int64{}, true, true},
+		{NewInt64(0, false), true, true},
+		{Int64From(0), false, true},
+		{Int64From(1), false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.in.IsZero(); got != tt.isZero {
+			t.Errorf("IsZero(%+v) = %v, want %v", tt.in, got, tt.isZero)
+		}
+		if got := tt.in.IsZeroOrNull(); got != tt.zeroOrNull {
+			t.Errorf("IsZeroOrNull(%+v) = %v, want %v", tt.in, got, tt.zeroOrNull)
+		}
+	}
+}
+
+func TestScanJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		bools   bool
+		in      interface{}
+		want    Int64
+		wantErr bool
+	}{
+		{"float", false, float64(12), Int64From(12), false},
+		{"fraction", false, 1.5, NewInt64(0, false), true},
+		{"out of range", false, 1e19, NewInt64(0, false), true},
+		{"NaN", false, math.NaN(), NewInt64(0, false), true},
+		{"number", false, json.Number("9007199254740993"), Int64From(9007199254740993), false},
+		{"string", false, "-4", Int64From(-4), false},
+		{"empty string", false, "", NewInt64(0, false), false},
+		{"nil", false, nil, NewInt64(0, false), false},
+		{"bool rejected", false, true, NewInt64(0, false), true},
+		{"bool true", true, true, Int64From(1), false},
+		{"bool false", true, false, Int64From(0), false},
+		{"object", false, map[string]interface{}{}, NewInt64(0, false), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBool(t, &ScanJSONBools, tt.bools)
+			var i Int64
+			err := i.ScanJSON(tt.in)
+			if (err != nil) != tt.wantErr || i != tt.want {
+				t.Errorf("ScanJSON(%v) = %+v, %v, want %+v", tt.in, i, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwap(t *testing.T) {
+	a, b := Int64From(1), NewInt64(0, false)
+	a.Swap(&b)
+	if a != NewInt64(0, false) || b != Int64From(1) {
+		t.Errorf("after Swap a = %+v, b = %+v", a, b)
+	}
+	c := Int64{}
+	b.Swap(&c)
+	if b != (Int64{}) || c != Int64From(1) {
+		t.Errorf("after Swap with unset b = %+v, c = %+v", b, c)
+	}
+}
+
+func TestTextNullWords(t *testing.T) {
+	old := TextNullWords
+	TextNullWords = []string{"null", "none"}
+	t.Cleanup(func() { TextNullWords = old })
+
+	tests := []struct {
+		in      string
+		want    Int64
+		wantErr bool
+	}{
+		{"null", NewInt64(0, false), false},
+		{"NULL", NewInt64(0, false), false},
+		{"None", NewInt64(0, false), false},
+		{"", NewInt64(0, false), false},
+		{"nil", NewInt64(0, false), true},
+		{"5", Int64From(5), false},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := i.UnmarshalText([]byte(tt.in))
+		if (err != nil) != tt.wantErr || i != tt.want {
+			t.Errorf("UnmarshalText(%q) = %+v, %v, want %+v", tt.in, i, err, tt.want)
+		}
+	}
+
+	TextNullWords = nil
+	var i Int64
+	if err := i.UnmarshalText([]byte("null")); err == nil {
+		t.Errorf("UnmarshalText(%q) with no TextNullWords = %+v, want error", "null", i)
+	}
+}
+
+func TestMaxDigits(t *testing.T) {
+	long := strings.Repeat("1", MaxDigits+4)
+	jsonDecode := func(i *Int64, s string) error { return i.UnmarshalJSON([]byte(s)) }
+	textDecode := func(i *Int64, s string) error { return i.UnmarshalText([]byte(s)) }
+	tests := []struct {
+		name    string
+		decode  func(*Int64, string) error
+		in      string
+		tooLong bool
+	}{
+		{"JSON max int", jsonDecode, "-9223372036854775808", false},
+		{"JSON quoted", jsonDecode, `"-9223372036854775808"`, false},
+		{"JSON too long", jsonDecode, long, true},
+		{"JSON quoted too long", jsonDecode, `"` + long + `"`, true},
+		{"text too long", textDecode, long, true},
+		{"text overflow", textDecode, "99999999999999999999", false},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := tt.decode(&i, tt.in)
+		var tl *TooLongError
+		if errors.As(err, &tl) != tt.tooLong {
+			t.Errorf("%s: error = %v, want TooLongError %v", tt.name, err, tt.tooLong)
+			continue
+		}
+		if tt.tooLong && (i.Valid || tl.MaxDigits != MaxDigits || tl.Length != len(tt.in)) {
+			t.Errorf("%s: = %+v, %+v", tt.name, i, tl)
+		}
+	}
+
+	old := MaxDigits
+	MaxDigits = 0
+	t.Cleanup(func() { MaxDigits = old })
+	var i Int64
+	long = "0000000000000000000000000042"
+	if err := i.UnmarshalText([]byte(long)); err != nil || i.Int64 != 42 {
+		t.Errorf("UnmarshalText(%q) with MaxDigits 0 = %+v, %v", long, i, err)
+	}
+}
+
+func TestScientificStrings(t *testing.T) {
+	setBool(t, &ScientificStrings, true)
+	tests := []struct {
+		data    string
+		want    Int64
+		wantErr bool
+	}{
+		{`"1e9"`, Int64From(1000000000), false},
+		{`"1.5e3"`, Int64From(1500), false},
+		{`"-2.50E2"`, Int64From(-250), false},
+		{`"9007199254740993e0"`, Int64From(9007199254740993), false},
+		{`"1200e-2"`, Int64From(12), false},
+		{`"0e5"`, Int64From(0), false},
+		{`"1.25e1"`, NewInt64(0, false), true},
+		{`"1e19"`, NewInt64(0, false), true},
+		{`"1ex"`, NewInt64(0, false), true},
+		{`"e3"`, NewInt64(0, false), true},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := i.UnmarshalJSON([]byte(tt.data))
+		if (err != nil) != tt.wantErr || i != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %+v, %v, want %+v", tt.data, i, err, tt.want)
+		}
+	}
+
+	ScientificStrings = false
+	var i Int64
+	if err := i.UnmarshalJSON([]byte(`"1e9"`)); err == nil {
+		t.Errorf("UnmarshalJSON(\"1e9\") without ScientificStrings = %+v, want error", i)
+	}
+}
+
+func TestNamedValue(t *testing.T) {
+	tests := []struct {
+		in   Int64
+		want interface{}
+	}{
+		{Int64From(3), int64(3)},
+		{Int64From(0), int64(0)},
+		{NewInt64(0, false), nil},
+		{Int64{}, nil},
+	}
+	for _, tt := range tests {
+		got := tt.in.NamedValue("id")
+		if got.Name != "id" || got.Value != tt.want {
+			t.Errorf("NamedValue(%+v) = %+v, want value %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRejectEmptyString(t *testing.T) {
+	var i Int64
+	if err := i.UnmarshalJSON([]byte(`""`)); err != nil || i != NewInt64(0, false) {
+		t.Errorf(`UnmarshalJSON("") = %+v, %v, want null`, i, err)
+	}
+
+	setBool(t, &RejectEmptyString, true)
+	i = Int64From(1)
+	if err := i.UnmarshalJSON([]byte(`""`)); err == nil || i != NewInt64(0, false) {
+		t.Errorf(`UnmarshalJSON("") with RejectEmptyString = %+v, %v, want error`, i, err)
+	}
+	if err := i.UnmarshalJSON([]byte(`null`)); err != nil || i != NewInt64(0, false) {
+		t.Errorf("UnmarshalJSON(null) with RejectEmptyString = %+v, %v", i, err)
+	}
+	if err := i.UnmarshalText(nil); err != nil || i != NewInt64(0, false) {
+		t.Errorf("UnmarshalText of empty text with RejectEmptyString = %+v, %v", i, err)
+	}
+}
+
+func TestValueFormatter(t *testing.T) {
+	old := ValueFormatter
+	ValueFormatter = func(n int64) string { return fmt.Sprintf("ID-%06d", n) }
+	t.Cleanup(func() { ValueFormatter = old })
+
+	tests := []struct {
+		in       Int64
+		wantJSON string
+		wantText string
+	}{
+		{Int64From(42), `"ID-000042"`, "ID-000042"},
+		{NewInt64(0, false), "null", ""},
+	}
+	for _, tt := range tests {
+		j, _ := tt.in.MarshalJSON()
+		text, _ := tt.in.MarshalText()
+		if string(j) != tt.wantJSON || string(text) != tt.wantText {
+			t.Errorf("%+v: MarshalJSON = %s, MarshalText = %q, want %s, %q", tt.in, j, text, tt.wantJSON, tt.wantText)
+		}
+	}
+
+	ValueFormatter = func(int64) string { return `a"b<` }
+	if j, _ := Int64From(1).MarshalJSON(); !json.Valid(j) {
+		t.Errorf("MarshalJSON with quoting formatter = %s, not valid JSON", j)
+	}
+}
+
+func TestParseErrorOffset(t *testing.T) {
+	tests := []struct {
+		decode     string
+		in         string
+		wantOffset int
+		wantMsg    string
+	}{
+		{"text", "12x4", 2, `nullint64: invalid character 'x' at offset 2 in "12x4"`},
+		{"text", "-", 1, `nullint64: unexpected end of input in "-"`},
+		{"text", "+-1", 1, `nullint64: invalid character '-' at offset 1 in "+-1"`},
+		{"json", `"9z"`, 1, `nullint64: invalid character 'z' at offset 1 in "9z"`},
+	}
+	for _, tt := range tests {
+		var i Int64
+		var err error
+		if tt.decode == "text" {
+			err = i.UnmarshalText([]byte(tt.in))
+		} else {
+			err = i.UnmarshalJSON([]byte(tt.in))
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s %s: error = %v, want *ParseError", tt.decode, tt.in, err)
+			continue
+		}
+		if pe.Offset != tt.wantOffset || pe.Error() != tt.wantMsg {
+			t.Errorf("%s %s: offset %d, %q, want %d, %q", tt.decode, tt.in, pe.Offset, pe.Error(), tt.wantOffset, tt.wantMsg)
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("%s %s: error doesn't wrap strconv.ErrSyntax", tt.decode, tt.in)
+		}
+	}
+}
+
+func TestObjectJSON(t *testing.T) {
+	setBool(t, &ObjectJSON, true)
+	tests := []struct {
+		data    string
+		want    Int64
+		wantErr bool
+	}{
+		{`{"value": 42, "valid": true}`, Int64From(42), false},
+		{`{"value": 0}`, Int64From(0), false},
+		{`{"value": 7, "valid": false}`, NewInt64(0, false), false},
+		{`{"valid": false}`, NewInt64(0, false), false},
+		{`{"value": null}`, NewInt64(0, false), false},
+		{`{}`, NewInt64(0, false), false},
+		{`{"valid": true}`, NewInt64(0, false), true},
+		{`{"value": "x", "valid": true}`, NewInt64(0, false), true},
+		{`{"value": 1.5}`, NewInt64(0, false), true},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := i.UnmarshalJSON([]byte(tt.data))
+		if (err != nil) != tt.wantErr || i != tt.want {
+			t.Errorf("UnmarshalJSON(%s) = %+v, %v, want %+v", tt.data, i, err, tt.want)
+		}
+	}
+
+	ObjectJSON = false
+	var i Int64
+	if err := i.UnmarshalJSON([]byte(`{"value": 1}`)); err == nil {
+		t.Errorf("UnmarshalJSON of object without ObjectJSON = %+v, want error", i)
+	}
+}
+
+func TestTextBase(t *testing.T) {
+	tests := []struct {
+		base int
+		in   Int64
+		text string
+	}{
+		{16, Int64From(255), "ff"},
+		{16, Int64From(-16), "-10"},
+		{2, Int64From(5), "101"},
+		{36, Int64From(35), "z"},
+		{10, Int64From(12), "12"},
+	}
+	for _, tt := range tests {
+		old := TextBase
+		TextBase = tt.base
+		text, err := tt.in.MarshalText()
+		if err != nil || string(text) != tt.text {
+			t.Errorf("base %d: MarshalText(%+v) = %q, %v, want %q", tt.base, tt.in, text, err, tt.text)
+		}
+		var back Int64
+		if err := back.UnmarshalText(text); err != nil || back != tt.in {
+			t.Errorf("base %d: UnmarshalText(%q) = %+v, %v", tt.base, text, back, err)
+		}
+		if err := back.UnmarshalJSON([]byte(`"` + tt.text + `"`)); err != nil || back != tt.in {
+			t.Errorf("base %d: UnmarshalJSON(%q) = %+v, %v", tt.base, text, back, err)
+		}
+		// JSON numbers are always decimal.
+		if j, _ := tt.in.MarshalJSON(); string(j) != strconv.FormatInt(tt.in.Int64, 10) {
+			t.Errorf("base %d: MarshalJSON(%+v) = %s", tt.base, tt.in, j)
+		}
+		TextBase = old
+	}
+}
+
+func TestTextBaseLongBinary(t *testing.T) {
+	old := TextBase
+	TextBase = 2
+	t.Cleanup(func() { TextBase = old })
+
+	in := Int64From(math.MinInt64)
+	text, _ := in.MarshalText()
+	var back Int64
+	if err := back.UnmarshalText(text); err != nil || back != in {
+		t.Errorf("UnmarshalText(%q) = %+v, %v: MaxDigits must allow for base 2", text, back, err)
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   int64
+		wantOK bool
+	}{
+		{"0", 0, true},
+		{"+12", 12, true},
+		{"-12", -12, true},
+		{"9223372036854775807", math.MaxInt64, true},
+		{"-9223372036854775808", math.MinInt64, true},
+		{"9223372036854775808", 0, false},
+		{"-9223372036854775809", 0, false},
+		{"99999999999999999999", 0, false},
+		{"", 0, false},
+		{"-", 0, false},
+		{"1_000", 0, false},
+		{" 1", 0, false},
+	}
+	for _, tt := range tests {
+		n, ok := parseDecimal([]byte(tt.in))
+		if n != tt.want || ok != tt.wantOK {
+			t.Errorf("parseDecimal(%q) = %d, %v, want %d, %v", tt.in, n, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestUnmarshalTextAllocs(t *testing.T) {
+	text := []byte("-1234567890")
+	var i Int64
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = i.UnmarshalText(text)
+	})
+	if allocs != 0 || i.Int64 != -1234567890 {
+		t.Errorf("UnmarshalText allocated %v times per call, want 0", allocs)
+	}
+}
+
+func BenchmarkParseDecimal(b *testing.B) {
+	text := []byte("-1234567890")
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		parseDecimal(text)
+	}
+}
+
+func BenchmarkUnmarshalText(b *testing.B) {
+	text := []byte("-1234567890")
+	var i Int64
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if err := i.UnmarshalText(text); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestUnmarshalJSONFastPath(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   int64
+		wantOK bool
+	}{
+		{`1234`, 1234, true},
+		{`-1234`, -1234, true},
+		{`0`, 0, true},
+		{`"42"`, 42, true},
+		{`"-42"`, -42, true},
+		{`9223372036854775807`, math.MaxInt64, true},
+		{`9223372036854775808`, 0, false},
+		{`012`, 0, false},
+		{`-012`, 0, false},
+		{`1.5`, 0, false},
+		{`1e3`, 0, false},
+		{`"4\u0032"`, 0, false},
+		{`"42`, 0, false},
+		{`null`, 0, false},
+		{``, 0, false},
+	}
+	for _, tt := range tests {
+		n, ok := parseJSONDecimal([]byte(tt.in))
+		if n != tt.want || ok != tt.wantOK {
+			t.Errorf("parseJSONDecimal(%s) = %d, %v, want %d, %v", tt.in, n, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	setBool(t, &RelaxedJSON, true)
+	if _, ok := parseJSONDecimal([]byte(`1`)); ok {
+		t.Error("parseJSONDecimal took the fast path with RelaxedJSON set")
+	}
+}
+
+func TestUnmarshalJSONAllocs(t *testing.T) {
+	for _, in := range []string{`-1234567890`, `"-1234567890"`} {
+		data := []byte(in)
+		var i Int64
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = i.UnmarshalJSON(data)
+		})
+		if allocs != 0 || i.Int64 != -1234567890 {
+			t.Errorf("UnmarshalJSON(%s) allocated %v times per call, want 0", in, allocs)
+		}
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	for _, bb := range []struct{ name, in string }{
+		{"number", `-1234567890`},
+		{"string", `"-1234567890"`},
+		{"escaped", `"-123456789\u0030"`}, // general path
+	} {
+		data := []byte(bb.in)
+		b.Run(bb.name, func(b *testing.B) {
+			var i Int64
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if err := i.UnmarshalJSON(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestMarshalNullAsEmptyString(t *testing.T) {
+	setBool(t, &MarshalNullAsEmptyString, true)
+	for _, in := range []Int64{NewInt64(0, false), Int64{}} {
+		got, err := in.MarshalJSON()
+		if err != nil || string(got) != `""` {
+			t.Errorf("MarshalJSON(%+v) = %s, %v, want \"\"", in, got, err)
+		}
+		var back Int64
+		if err := back.UnmarshalJSON(got); err != nil || back != NewInt64(0, false) {
+			t.Errorf("UnmarshalJSON(%s) = %+v, %v, want null", got, back, err)
+		}
+	}
+	if got, _ := Int64From(0).MarshalJSON(); string(got) != "0" {
+		t.Errorf("MarshalJSON(0) = %s, want 0", got)
+	}
+
+	// MarshalNullAsZero takes precedence.
+	setBool(t, &MarshalNullAsZero, true)
+	if got, _ := NewInt64(0, false).MarshalJSON(); string(got) != "0" {
+		t.Errorf("MarshalJSON(null) with both options = %s, want 0", got)
+	}
+}
+
+func TestJSONLen(t *testing.T) {
+	values := []Int64{
+		Int64From(0), Int64From(9), Int64From(10), Int64From(-1), Int64From(-10),
+		Int64From(math.MaxInt64), Int64From(math.MinInt64), NewInt64(0, false), {},
+	}
+	options := []struct {
+		name string
+		set  func(t *testing.T)
+	}{
+		{"default", func(t *testing.T) {}},
+		{"null as zero", func(t *testing.T) { setBool(t, &MarshalNullAsZero, true) }},
+		{"null as empty string", func(t *testing.T) { setBool(t, &MarshalNullAsEmptyString, true) }},
+		{"value formatter", func(t *testing.T) {
+			old := ValueFormatter
+			ValueFormatter = func(n int64) string { return fmt.Sprintf("#%d", n) }
+			t.Cleanup(func() { ValueFormatter = old })
+		}},
+	}
+	for _, opt := range options {
+		t.Run(opt.name, func(t *testing.T) {
+			opt.set(t)
+			for _, v := range values {
+				b, _ := v.MarshalJSON()
+				if got := v.JSONLen(); got != len(b) {
+					t.Errorf("JSONLen(%+v) = %d, MarshalJSON gives %d bytes", v, got, len(b))
+				}
+			}
+		})
+	}
+}
+
+func TestTrailingData(t *testing.T) {
+	tests := []struct {
+		name       string
+		json       bool
+		in         string
+		wantOffset int
+	}{
+		{"json bare", true, `42abc`, 2},
+		{"json quoted", true, `"42abc"`, 2},
+		{"json two numbers", true, `1 2`, 1},
+		{"text", false, `42 `, 2},
+		{"text trailing sign", false, `7-`, 1},
+	}
+	for _, tt := range tests {
+		var i Int64
+		var err error
+		if tt.json {
+			err = i.UnmarshalJSON([]byte(tt.in))
+		} else {
+			err = i.UnmarshalText([]byte(tt.in))
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Offset != tt.wantOffset {
+			t.Errorf("%s: %s error = %v, want ParseError at offset %d", tt.name, tt.in, err, tt.wantOffset)
+		}
+		if i.Valid {
+			t.Errorf("%s: %s decoded as %+v", tt.name, tt.in, i)
+		}
+	}
+
+	var i Int64
+	if err := i.UnmarshalJSON([]byte(" 42 \n")); err != nil || i != Int64From(42) {
+		t.Errorf("UnmarshalJSON with surrounding whitespace = %+v, %v", i, err)
+	}
+}
@@ -0,0 +1,176 @@
+package nullint64
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+// Compile-time checks that the types in this package keep implementing the
+// interfaces they advertise. Marshalers have value receivers and
+// unmarshalers pointer receivers, so both forms are listed where relevant.
+var (
+	_ json.Marshaler             = Int64{}
+	_ json.Unmarshaler           = (*Int64)(nil)
+	_ encoding.TextMarshaler     = Int64{}
+	_ encoding.TextUnmarshaler   = (*Int64)(nil)
+	_ textAppender               = Int64{}
+	_ sql.Scanner                = (*Int64)(nil)
+	_ driver.Valuer              = Int64{}
+	_ encoding.BinaryMarshaler   = Int64{}
+	_ encoding.BinaryUnmarshaler = (*Int64)(nil)
+	_ gob.GobEncoder             = Int64{}
+	_ gob.GobDecoder             = (*Int64)(nil)
+	_ fmt.GoStringer             = Int64{}
+	_ fmt.Stringer               = Int64{}
+	_ fmt.Formatter              = Int64{}
+	_ xml.Marshaler              = Int64{}
+	_ xml.Unmarshaler            = (*Int64)(nil)
+	_ xml.MarshalerAttr          = Int64{}
+	_ xml.UnmarshalerAttr        = (*Int64)(nil)
+
+	_ json.Marshaler           = Float64{}
+	_ json.Unmarshaler         = (*Float64)(nil)
+	_ encoding.TextMarshaler   = Float64{}
+	_ encoding.TextUnmarshaler = (*Float64)(nil)
+	_ sql.Scanner              = (*Float64)(nil)
+	_ driver.Valuer            = Float64{}
+
+	_ json.Marshaler           = String{}
+	_ json.Unmarshaler         = (*String)(nil)
+	_ encoding.TextMarshaler   = String{}
+	_ encoding.TextUnmarshaler = (*String)(nil)
+	_ sql.Scanner              = (*String)(nil)
+	_ driver.Valuer            = String{}
+
+	_ json.Marshaler           = Bool{}
+	_ json.Unmarshaler         = (*Bool)(nil)
+	_ encoding.TextMarshaler   = Bool{}
+	_ encoding.TextUnmarshaler = (*Bool)(nil)
+	_ sql.Scanner              = (*Bool)(nil)
+	_ driver.Valuer            = Bool{}
+
+	_ json.Marshaler           = Time{}
+	_ json.Unmarshaler         = (*Time)(nil)
+	_ encoding.TextMarshaler   = Time{}
+	_ encoding.TextUnmarshaler = (*Time)(nil)
+	_ sql.Scanner              = (*Time)(nil)
+	_ driver.Valuer            = Time{}
+
+	_ json.Marshaler           = Int32{}
+	_ json.Unmarshaler         = (*Int32)(nil)
+	_ encoding.TextMarshaler   = Int32{}
+	_ encoding.TextUnmarshaler = (*Int32)(nil)
+	_ sql.Scanner              = (*Int32)(nil)
+	_ driver.Valuer            = Int32{}
+
+	_ json.Marshaler           = Int16{}
+	_ json.Unmarshaler         = (*Int16)(nil)
+	_ encoding.TextMarshaler   = Int16{}
+	_ encoding.TextUnmarshaler = (*Int16)(nil)
+	_ sql.Scanner              = (*Int16)(nil)
+	_ driver.Valuer            = Int16{}
+
+	_ json.Marshaler           = Int8{}
+	_ json.Unmarshaler         = (*Int8)(nil)
+	_ encoding.TextMarshaler   = Int8{}
+	_ encoding.TextUnmarshaler = (*Int8)(nil)
+	_ sql.Scanner              = (*Int8)(nil)
+	_ driver.Valuer            = Int8{}
+
+	_ json.Marshaler           = Uint32{}
+	_ json.Unmarshaler         = (*Uint32)(nil)
+	_ encoding.TextMarshaler   = Uint32{}
+	_ encoding.TextUnmarshaler = (*Uint32)(nil)
+	_ sql.Scanner              = (*Uint32)(nil)
+	_ driver.Valuer            = Uint32{}
+
+	_ json.Marshaler           = Uint64{}
+	_ json.Unmarshaler         = (*Uint64)(nil)
+	_ encoding.TextMarshaler   = Uint64{}
+	_ encoding.TextUnmarshaler = (*Uint64)(nil)
+	_ sql.Scanner              = (*Uint64)(nil)
+	_ driver.Valuer            = Uint64{}
+
+	_ json.Marshaler           = BigInt{}
+	_ json.Unmarshaler         = (*BigInt)(nil)
+	_ encoding.TextMarshaler   = BigInt{}
+	_ encoding.TextUnmarshaler = (*BigInt)(nil)
+	_ sql.Scanner              = (*BigInt)(nil)
+	_ driver.Valuer            = BigInt{}
+
+	_ json.Marshaler   = PaddedInt64{}
+	_ json.Unmarshaler = (*PaddedInt64)(nil)
+
+	_ json.Marshaler   = Int64String{}
+	_ json.Unmarshaler = (*Int64String)(nil)
+
+	_ json.Marshaler   = PreservedInt64{}
+	_ json.Unmarshaler = (*PreservedInt64)(nil)
+
+	_ encoding.BinaryMarshaler   = CachedInt64{}
+	_ encoding.BinaryUnmarshaler = (*CachedInt64)(nil)
+
+	_ fmt.Stringer = State(0)
+
+	// Every type nullgen can wrap supports the full set of encodings.
+	_ encoder = Int64{}
+	_ decoder = (*Int64)(nil)
+	_ encoder = Float64{}
+	_ decoder = (*Float64)(nil)
+	_ encoder = String{}
+	_ decoder = (*String)(nil)
+	_ encoder = Bool{}
+	_ decoder = (*Bool)(nil)
+	_ encoder = Time{}
+	_ decoder = (*Time)(nil)
+	_ encoder = Int32{}
+	_ decoder = (*Int32)(nil)
+	_ encoder = Int16{}
+	_ decoder = (*Int16)(nil)
+	_ encoder = Int8{}
+	_ decoder = (*Int8)(nil)
+	_ encoder = Uint32{}
+	_ decoder = (*Uint32)(nil)
+	_ encoder = Uint64{}
+	_ decoder = (*Uint64)(nil)
+)
+
+// textAppender mirrors encoding.TextAppender, which is only defined from
+// Go 1.24.
+type textAppender interface {
+	AppendText(b []byte) ([]byte, error)
+}
+
+// encoder and decoder list every marshaler and unmarshaler the types
+// nullgen wraps have, including those of packages this one doesn't import.
+type encoder interface {
+	json.Marshaler
+	encoding.TextMarshaler
+	textAppender
+	encoding.BinaryMarshaler
+	gob.GobEncoder
+	xml.Marshaler
+	xml.MarshalerAttr
+	MarshalYAML() (interface{}, error)
+	MarshalCBOR() ([]byte, error)
+	MarshalMsgpack() ([]byte, error)
+	driver.Valuer
+}
+
+type decoder interface {
+	json.Unmarshaler
+	encoding.TextUnmarshaler
+	encoding.BinaryUnmarshaler
+	gob.GobDecoder
+	xml.Unmarshaler
+	xml.UnmarshalerAttr
+	UnmarshalYAML(unmarshal func(interface{}) error) error
+	UnmarshalCBOR(data []byte) error
+	UnmarshalMsgpack(data []byte) error
+	sql.Scanner
+}
@@ -0,0 +1,129 @@
+// Package convert converts database driver values into Go values for the
+// Scan methods of the nullable types, following the rules of database/sql.
+package convert
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ConvertAssign stores the non-nil driver value src in the value dest
+// points to, converting it as database/sql does for a scan into a basic
+// Go type: numbers, strings and bytes convert between each other by
+// parsing or formatting, with range checks for the width of dest, and
+// bools accept the forms of driver.Bool. dest must be a pointer to a
+// value of integer, float, string or bool kind.
+func ConvertAssign(dest, src interface{}) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("destination %T is not a non-nil pointer", dest)
+	}
+	dv = dv.Elem()
+
+	sv := reflect.ValueOf(src)
+	if sv.IsValid() && sv.Type().AssignableTo(dv.Type()) {
+		switch src.(type) {
+		case []byte:
+			// Fall through to copy the bytes out of the driver buffer.
+		default:
+			dv.Set(sv)
+			return nil
+		}
+	}
+
+	switch dv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s := asString(src)
+		n, err := strconv.ParseInt(s, 10, dv.Type().Bits())
+		if err != nil {
+			return conversionError(src, s, dv.Kind(), err)
+		}
+		dv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s := asString(src)
+		n, err := strconv.ParseUint(s, 10, dv.Type().Bits())
+		if err != nil {
+			return conversionError(src, s, dv.Kind(), err)
+		}
+		dv.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		s := asString(src)
+		f, err := strconv.ParseFloat(s, dv.Type().Bits())
+		if err != nil {
+			return conversionError(src, s, dv.Kind(), err)
+		}
+		dv.SetFloat(f)
+		return nil
+	case reflect.String:
+		switch v := src.(type) {
+		case time.Time:
+			dv.SetString(v.Format(time.RFC3339Nano))
+			return nil
+		case bool:
+			dv.SetString(strconv.FormatBool(v))
+			return nil
+		}
+		switch sv.Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			dv.SetString(asString(src))
+			return nil
+		case reflect.Slice:
+			if sv.Type().Elem().Kind() == reflect.Uint8 {
+				dv.SetString(string(sv.Bytes()))
+				return nil
+			}
+		}
+	case reflect.Bool:
+		v, err := driver.Bool.ConvertValue(src)
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%v) to a bool: %w", src, src, err)
+		}
+		dv.SetBool(v.(bool))
+		return nil
+	}
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, dest)
+}
+
+// asString formats src as text for parsing.
+func asString(src interface{}) string {
+	switch v := src.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	rv := reflect.ValueOf(src)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits())
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes())
+		}
+	}
+	return fmt.Sprintf("%v", src)
+}
+
+// conversionError reports a failure to parse s, the text form of src, as a
+// value of kind k.
+func conversionError(src interface{}, s string, k reflect.Kind, err error) error {
+	if ne, ok := err.(*strconv.NumError); ok {
+		err = ne.Err
+	}
+	return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, s, k, err)
+}
@@ -0,0 +1,16 @@
+//go:build go1.23
+
+package nullint64
+
+import "iter"
+
+// All returns an iterator yielding this Int64's value once if it is valid,
+// and nothing if it is null, so the body of a range loop over it runs only
+// when a value is present.
+func (i Int64) All() iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		if i.Valid {
+			yield(i.Int64)
+		}
+	}
+}
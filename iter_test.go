@@ -0,0 +1,37 @@
+//go:build go1.23
+
+package nullint64
+
+import "testing"
+
+func TestAll(t *testing.T) {
+	tests := []struct {
+		in   Int64
+		want []int64
+	}{
+		{Int64From(5), []int64{5}},
+		{Int64From(0), []int64{0}},
+		{NewInt64(0, false), nil},
+		{Int64{}, nil},
+	}
+	for _, tt := range tests {
+		var got []int64
+		for v := range tt.in.All() {
+			got = append(got, v)
+		}
+		if len(got) != len(tt.want) || (len(got) == 1 && got[0] != tt.want[0]) {
+			t.Errorf("All(%+v) yielded %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAllBreak(t *testing.T) {
+	n := 0
+	for range Int64From(1).All() {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("loop ran %d times, want 1", n)
+	}
+}
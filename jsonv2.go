@@ -0,0 +1,71 @@
+//go:build goexperiment.jsonv2
+
+package nullint64
+
+import "encoding/json/jsontext"
+
+// MarshalJSONTo implements the encoding/json/v2 MarshalerTo interface,
+// writing the same encoding as MarshalJSON straight to enc.
+func (i Int64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteValue(i.AppendJSON(nil))
+}
+
+// UnmarshalJSONFrom implements the encoding/json/v2 UnmarshalerFrom
+// interface, decoding the next value from dec as UnmarshalJSON does.
+func (i *Int64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	v, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return i.UnmarshalJSON(v)
+}
+
+// The wrapper types below would otherwise inherit Int64's methods, which
+// encoding/json/v2 prefers over their own MarshalJSON and UnmarshalJSON.
+
+// MarshalJSONTo implements the encoding/json/v2 MarshalerTo interface.
+func (p PaddedInt64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	b, err := p.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
+
+// UnmarshalJSONFrom implements the encoding/json/v2 UnmarshalerFrom
+// interface.
+func (p *PaddedInt64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	v, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalJSON(v)
+}
+
+// MarshalJSONTo implements the encoding/json/v2 MarshalerTo interface.
+func (p PreservedInt64) MarshalJSONTo(enc *jsontext.Encoder) error {
+	b, err := p.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
+
+// UnmarshalJSONFrom implements the encoding/json/v2 UnmarshalerFrom
+// interface.
+func (p *PreservedInt64) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	v, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalJSON(v)
+}
+
+// MarshalJSONTo implements the encoding/json/v2 MarshalerTo interface.
+func (s Int64String) MarshalJSONTo(enc *jsontext.Encoder) error {
+	b, err := s.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
@@ -0,0 +1,101 @@
+package nullint64
+
+// The comparisons below follow SQL's three-valued logic: comparing with a
+// null, or an unset value, yields a null Bool rather than false, as a
+// WHERE clause evaluates them. Combine the results with And, Or and Not,
+// and use IsTrue to decide whether a row matches.
+
+// compare3 returns a null Bool if either operand is null, and the result
+// of cmp applied to both values otherwise.
+func compare3(a, b Int64, cmp func(x, y int64) bool) Bool {
+	if !a.Valid || !b.Valid {
+		return NewBool(false, false)
+	}
+	return BoolFrom(cmp(a.Int64, b.Int64))
+}
+
+// EqualTo returns i = other under three-valued logic. Unlike Equal, two
+// nulls are not equal: the result is null.
+func (i Int64) EqualTo(other Int64) Bool {
+	return compare3(i, other, func(x, y int64) bool { return x == y })
+}
+
+// NotEqualTo returns i <> other under three-valued logic.
+func (i Int64) NotEqualTo(other Int64) Bool {
+	return compare3(i, other, func(x, y int64) bool { return x != y })
+}
+
+// LessThan returns i < other under three-valued logic.
+func (i Int64) LessThan(other Int64) Bool {
+	return compare3(i, other, func(x, y int64) bool { return x < y })
+}
+
+// LessThanOrEqual returns i <= other under three-valued logic.
+func (i Int64) LessThanOrEqual(other Int64) Bool {
+	return compare3(i, other, func(x, y int64) bool { return x <= y })
+}
+
+// GreaterThan returns i > other under three-valued logic.
+func (i Int64) GreaterThan(other Int64) Bool {
+	return compare3(i, other, func(x, y int64) bool { return x > y })
+}
+
+// GreaterThanOrEqual returns i >= other under three-valued logic.
+func (i Int64) GreaterThanOrEqual(other Int64) Bool {
+	return compare3(i, other, func(x, y int64) bool { return x >= y })
+}
+
+// In returns i IN (vs...) under three-valued logic: true if i equals one of
+// vs, otherwise null if i or any of vs is null, and false otherwise.
+func (i Int64) In(vs ...Int64) Bool {
+	result := BoolFrom(false)
+	for _, v := range vs {
+		result = result.Or(i.EqualTo(v))
+	}
+	return result
+}
+
+// IsNull returns i IS NULL, which is never null itself.
+func (i Int64) IsNull() Bool {
+	return BoolFrom(!i.Valid)
+}
+
+// And returns b AND other under three-valued logic: false if either is
+// false, otherwise null if either is null, and true otherwise.
+func (b Bool) And(other Bool) Bool {
+	switch {
+	case b.Valid && !b.Bool, other.Valid && !other.Bool:
+		return BoolFrom(false)
+	case !b.Valid || !other.Valid:
+		return NewBool(false, false)
+	default:
+		return BoolFrom(true)
+	}
+}
+
+// Or returns b OR other under three-valued logic: true if either is true,
+// otherwise null if either is null, and false otherwise.
+func (b Bool) Or(other Bool) Bool {
+	switch {
+	case b.Valid && b.Bool, other.Valid && other.Bool:
+		return BoolFrom(true)
+	case !b.Valid || !other.Valid:
+		return NewBool(false, false)
+	default:
+		return BoolFrom(false)
+	}
+}
+
+// Not returns NOT b under three-valued logic, which is null if b is null.
+func (b Bool) Not() Bool {
+	if !b.Valid {
+		return NewBool(false, false)
+	}
+	return BoolFrom(!b.Bool)
+}
+
+// IsTrue reports whether b is valid and true, the test a WHERE clause
+// applies to its condition.
+func (b Bool) IsTrue() bool {
+	return b.Valid && b.Bool
+}
@@ -0,0 +1,401 @@
+package nullint64
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+// MessagePack format bytes used by MarshalMsgpack and UnmarshalMsgpack.
+const (
+	msgpackNil     = 0xc0
+	msgpackFalse   = 0xc2
+	msgpackTrue    = 0xc3
+	msgpackExt8    = 0xc7
+	msgpackFloat32 = 0xca
+	msgpackFloat64 = 0xcb
+	msgpackUint8   = 0xcc
+	msgpackUint16  = 0xcd
+	msgpackUint32  = 0xce
+	msgpackUint64  = 0xcf
+	msgpackInt8    = 0xd0
+	msgpackInt16   = 0xd1
+	msgpackInt32   = 0xd2
+	msgpackInt64   = 0xd3
+	msgpackFixext4 = 0xd6
+	msgpackFixext8 = 0xd7
+	msgpackStr8    = 0xd9
+	msgpackStr16   = 0xda
+	msgpackStr32   = 0xdb
+
+	// msgpackFixstr is the prefix of strings of up to 31 bytes, whose
+	// length is in the low five bits.
+	msgpackFixstr = 0xa0
+
+	// msgpackTimestamp is the extension type of MessagePack timestamps.
+	msgpackTimestamp = -1
+)
+
+// appendMsgpackUint appends u in the smallest format that holds it.
+func appendMsgpackUint(b []byte, u uint64) []byte {
+	switch {
+	case u <= math.MaxInt8:
+		// Positive fixint.
+		return append(b, byte(u))
+	case u <= math.MaxUint8:
+		return append(b, msgpackUint8, byte(u))
+	case u <= math.MaxUint16:
+		return append(b, msgpackUint16, byte(u>>8), byte(u))
+	case u <= math.MaxUint32:
+		return append(b, msgpackUint32, byte(u>>24), byte(u>>16), byte(u>>8), byte(u))
+	default:
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], u)
+		return append(append(b, msgpackUint64), buf[:]...)
+	}
+}
+
+// parseMsgpackInt decodes the MessagePack integer that data holds, and
+// nothing else, as the signed value n or, for unsigned values too large
+// for it, as big with ok false.
+func parseMsgpackInt(data []byte, typ string) (n int64, big uint64, ok bool, err error) {
+	if len(data) == 0 {
+		return 0, 0, false, errors.New("nullint64: empty MessagePack value")
+	}
+	code, body := data[0], data[1:]
+	var size int
+	switch code {
+	case msgpackUint8, msgpackInt8:
+		size = 1
+	case msgpackUint16, msgpackInt16:
+		size = 2
+	case msgpackUint32, msgpackInt32:
+		size = 4
+	case msgpackUint64, msgpackInt64:
+		size = 8
+	default:
+		if code <= 0x7f || code >= 0xe0 {
+			// Positive and negative fixint.
+			size = 0
+			break
+		}
+		return 0, 0, false, fmt.Errorf("nullint64: cannot unmarshal MessagePack code 0x%02x into %s", code, typ)
+	}
+	if len(body) != size {
+		return 0, 0, false, errors.New("nullint64: invalid MessagePack length")
+	}
+	switch code {
+	case msgpackUint8:
+		n = int64(body[0])
+	case msgpackUint16:
+		n = int64(binary.BigEndian.Uint16(body))
+	case msgpackUint32:
+		n = int64(binary.BigEndian.Uint32(body))
+	case msgpackUint64:
+		u := binary.BigEndian.Uint64(body)
+		if u > math.MaxInt64 {
+			return 0, u, false, nil
+		}
+		n = int64(u)
+	case msgpackInt8:
+		n = int64(int8(body[0]))
+	case msgpackInt16:
+		n = int64(int16(binary.BigEndian.Uint16(body)))
+	case msgpackInt32:
+		n = int64(int32(binary.BigEndian.Uint32(body)))
+	case msgpackInt64:
+		n = int64(binary.BigEndian.Uint64(body))
+	default:
+		n = int64(int8(code))
+	}
+	return n, 0, true, nil
+}
+
+// isMsgpackNil reports whether data starts with a MessagePack nil,
+// failing if anything follows it.
+func isMsgpackNil(data []byte) (bool, error) {
+	if len(data) == 0 {
+		return false, errors.New("nullint64: empty MessagePack value")
+	}
+	if data[0] != msgpackNil {
+		return false, nil
+	}
+	if len(data) != 1 {
+		return false, errors.New("nullint64: invalid MessagePack length")
+	}
+	return true, nil
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface of
+// vmihailenco/msgpack, encoding null as MessagePack nil and a valid value
+// in the smallest integer format that holds it. It needs no import of the
+// msgpack package, and takes precedence there over MarshalBinary.
+func (i Int64) MarshalMsgpack() ([]byte, error) {
+	if !i.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	n := i.Int64
+	switch {
+	case n >= -32 && n <= math.MaxInt8:
+		// Positive and negative fixint.
+		return []byte{byte(n)}, nil
+	case n >= math.MinInt8 && n <= math.MaxInt8:
+		return []byte{msgpackInt8, byte(n)}, nil
+	case n >= math.MinInt16 && n <= math.MaxInt16:
+		b := []byte{msgpackInt16, 0, 0}
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+		return b, nil
+	case n >= math.MinInt32 && n <= math.MaxInt32:
+		b := []byte{msgpackInt32, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+		return b, nil
+	default:
+		b := make([]byte, 9)
+		b[0] = msgpackInt64
+		binary.BigEndian.PutUint64(b[1:], uint64(n))
+		return b, nil
+	}
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface of
+// vmihailenco/msgpack. It accepts nil, as null, and every MessagePack
+// integer format; unsigned values above math.MaxInt64 are an error.
+//
+// The msgpack decoder resets a field to its zero value on nil without
+// calling UnmarshalMsgpack, so through it an explicit nil leaves Set false,
+// the same as an absent key.
+func (i *Int64) UnmarshalMsgpack(data []byte) error {
+	i.Set = true
+	i.Int64, i.Valid = 0, false
+	if null, err := isMsgpackNil(data); null || err != nil {
+		return err
+	}
+	n, big, ok, err := parseMsgpackInt(data, "Int64")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("nullint64: MessagePack integer %d overflows Int64", big)
+	}
+	i.Int64, i.Valid = n, true
+	return nil
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface, encoding null
+// as MessagePack nil and a valid value in the smallest unsigned format
+// that holds it.
+func (u Uint64) MarshalMsgpack() ([]byte, error) {
+	if !u.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	return appendMsgpackUint(nil, u.Uint64), nil
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface. It
+// accepts nil, as null, and every MessagePack integer format; negative
+// values are an error. As for Int64, the msgpack decoder doesn't call it
+// for nil.
+func (u *Uint64) UnmarshalMsgpack(data []byte) error {
+	u.Set = true
+	u.Uint64, u.Valid = 0, false
+	if null, err := isMsgpackNil(data); null || err != nil {
+		return err
+	}
+	n, big, ok, err := parseMsgpackInt(data, "Uint64")
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		u.Uint64 = big
+	case n < 0:
+		return fmt.Errorf("nullint64: MessagePack integer %d overflows Uint64", n)
+	default:
+		u.Uint64 = uint64(n)
+	}
+	u.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface, encoding null
+// as MessagePack nil and a valid value as a float 64.
+func (f Float64) MarshalMsgpack() ([]byte, error) {
+	if !f.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	b := make([]byte, 9)
+	b[0] = msgpackFloat64
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(f.Float64))
+	return b, nil
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface. It
+// accepts nil, as null, both float formats and every integer format.
+func (f *Float64) UnmarshalMsgpack(data []byte) error {
+	f.Set = true
+	f.Float64, f.Valid = 0, false
+	if null, err := isMsgpackNil(data); null || err != nil {
+		return err
+	}
+	switch code, body := data[0], data[1:]; {
+	case code == msgpackFloat32 && len(body) == 4:
+		f.Float64 = float64(math.Float32frombits(binary.BigEndian.Uint32(body)))
+	case code == msgpackFloat64 && len(body) == 8:
+		f.Float64 = math.Float64frombits(binary.BigEndian.Uint64(body))
+	case code == msgpackFloat32 || code == msgpackFloat64:
+		return errors.New("nullint64: invalid MessagePack length")
+	default:
+		n, big, ok, err := parseMsgpackInt(data, "Float64")
+		if err != nil {
+			return err
+		}
+		f.Float64 = float64(n)
+		if !ok {
+			f.Float64 = float64(big)
+		}
+	}
+	f.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface, encoding null
+// as MessagePack nil and a valid value as true or false.
+func (b Bool) MarshalMsgpack() ([]byte, error) {
+	switch {
+	case !b.Valid:
+		return []byte{msgpackNil}, nil
+	case b.Bool:
+		return []byte{msgpackTrue}, nil
+	default:
+		return []byte{msgpackFalse}, nil
+	}
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface. It
+// accepts nil, as null, true and false.
+func (b *Bool) UnmarshalMsgpack(data []byte) error {
+	b.Set = true
+	b.Bool, b.Valid = false, false
+	if null, err := isMsgpackNil(data); null || err != nil {
+		return err
+	}
+	if len(data) != 1 || (data[0] != msgpackTrue && data[0] != msgpackFalse) {
+		return errors.New("nullint64: cannot unmarshal MessagePack value into Bool")
+	}
+	b.Bool, b.Valid = data[0] == msgpackTrue, true
+	return nil
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface, encoding null
+// as MessagePack nil and a valid value as a str in the smallest format
+// that holds it.
+func (s String) MarshalMsgpack() ([]byte, error) {
+	if !s.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	n := len(s.String)
+	var b []byte
+	switch {
+	case n < 32:
+		b = []byte{msgpackFixstr | byte(n)}
+	case n <= math.MaxUint8:
+		b = []byte{msgpackStr8, byte(n)}
+	case n <= math.MaxUint16:
+		b = []byte{msgpackStr16, byte(n >> 8), byte(n)}
+	default:
+		b = []byte{msgpackStr32, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}
+	}
+	return append(b, s.String...), nil
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface. It
+// accepts nil, as null, and every str format.
+func (s *String) UnmarshalMsgpack(data []byte) error {
+	s.Set = true
+	s.String, s.Valid = "", false
+	if null, err := isMsgpackNil(data); null || err != nil {
+		return err
+	}
+	code, body := data[0], data[1:]
+	var n int
+	switch {
+	case code&0xe0 == msgpackFixstr:
+		n = int(code & 0x1f)
+	case code == msgpackStr8 && len(body) >= 1:
+		n, body = int(body[0]), body[1:]
+	case code == msgpackStr16 && len(body) >= 2:
+		n, body = int(binary.BigEndian.Uint16(body)), body[2:]
+	case code == msgpackStr32 && len(body) >= 4:
+		n, body = int(binary.BigEndian.Uint32(body)), body[4:]
+	case code == msgpackStr8 || code == msgpackStr16 || code == msgpackStr32:
+		return errors.New("nullint64: invalid MessagePack length")
+	default:
+		return fmt.Errorf("nullint64: cannot unmarshal MessagePack code 0x%02x into String", code)
+	}
+	if len(body) != n {
+		return errors.New("nullint64: invalid MessagePack length")
+	}
+	if !utf8.Valid(body) {
+		return errors.New("nullint64: invalid UTF-8 in MessagePack str")
+	}
+	s.String, s.Valid = string(body), true
+	return nil
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface, encoding null
+// as MessagePack nil and a valid value as a MessagePack timestamp, in the
+// smallest of its formats that holds it. The timestamp doesn't record the
+// zone.
+func (t Time) MarshalMsgpack() ([]byte, error) {
+	if !t.Valid {
+		return []byte{msgpackNil}, nil
+	}
+	sec, nsec := t.Time.Unix(), uint64(t.Time.Nanosecond())
+	switch {
+	case sec >= 0 && sec <= math.MaxUint32 && nsec == 0:
+		b := []byte{msgpackFixext4, byte(msgpackTimestamp & 0xff), 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(b[2:], uint32(sec))
+		return b, nil
+	case sec >= 0 && sec < 1<<34:
+		b := []byte{msgpackFixext8, byte(msgpackTimestamp & 0xff), 0, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint64(b[2:], nsec<<34|uint64(sec))
+		return b, nil
+	default:
+		b := make([]byte, 15)
+		b[0], b[1], b[2] = msgpackExt8, 12, byte(msgpackTimestamp&0xff)
+		binary.BigEndian.PutUint32(b[3:], uint32(nsec))
+		binary.BigEndian.PutUint64(b[7:], uint64(sec))
+		return b, nil
+	}
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface. It
+// accepts nil, as null, and every MessagePack timestamp format, decoding
+// the time in UTC.
+func (t *Time) UnmarshalMsgpack(data []byte) error {
+	t.Set = true
+	t.Time, t.Valid = time.Time{}, false
+	if null, err := isMsgpackNil(data); null || err != nil {
+		return err
+	}
+	var sec, nsec int64
+	switch {
+	case len(data) == 6 && data[0] == msgpackFixext4 && int8(data[1]) == msgpackTimestamp:
+		sec = int64(binary.BigEndian.Uint32(data[2:]))
+	case len(data) == 10 && data[0] == msgpackFixext8 && int8(data[1]) == msgpackTimestamp:
+		v := binary.BigEndian.Uint64(data[2:])
+		sec, nsec = int64(v&(1<<34-1)), int64(v>>34)
+	case len(data) == 15 && data[0] == msgpackExt8 && data[1] == 12 && int8(data[2]) == msgpackTimestamp:
+		nsec = int64(binary.BigEndian.Uint32(data[3:]))
+		sec = int64(binary.BigEndian.Uint64(data[7:]))
+	default:
+		return errors.New("nullint64: cannot unmarshal MessagePack value into Time")
+	}
+	if nsec >= 1e9 {
+		return errors.New("nullint64: invalid MessagePack timestamp")
+	}
+	t.Time, t.Valid = time.Unix(sec, nsec).UTC(), true
+	return nil
+}
@@ -0,0 +1,141 @@
+package nullint64
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type msgpackEncoder interface {
+	MarshalMsgpack() ([]byte, error)
+}
+
+type msgpackDecoder interface {
+	UnmarshalMsgpack(data []byte) error
+}
+
+func TestMarshalMsgpack(t *testing.T) {
+	tests := []struct {
+		in   msgpackEncoder
+		want []byte
+	}{
+		{Int64From(0), []byte{0x00}},
+		{Int64From(-32), []byte{0xe0}},
+		{Int64From(-33), []byte{0xd0, 0xdf}},
+		{Int64From(200), []byte{0xd1, 0x00, 0xc8}},
+		{Int64From(math.MinInt64), []byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+		{NewInt64(0, false), []byte{0xc0}},
+		{Uint64From(200), []byte{0xcc, 0xc8}},
+		{Uint64From(math.MaxUint64), []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{Float64From(1.5), []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{BoolFrom(true), []byte{0xc3}},
+		{BoolFrom(false), []byte{0xc2}},
+		{StringFrom("abc"), []byte{0xa3, 'a', 'b', 'c'}},
+		{StringFrom(""), []byte{0xa0}},
+		{TimeFrom(time.Unix(1, 0)), []byte{0xd6, 0xff, 0, 0, 0, 1}},
+		{TimeFrom(time.Unix(1, 1)), []byte{0xd7, 0xff, 0, 0, 0, 0x04, 0, 0, 0, 1}},
+		{TimeFrom(time.Unix(-1, 0)), []byte{0xc7, 12, 0xff, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{NewTime(time.Time{}, false), []byte{0xc0}},
+		{Int8From(-1), []byte{0xff}},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.MarshalMsgpack()
+		if err != nil || !bytes.Equal(got, tt.want) {
+			t.Errorf("%T.MarshalMsgpack(%+v) = %x, %v, want %x", tt.in, tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, n := range []int{31, 32, 256, 65536} {
+		s := StringFrom(strings.Repeat("x", n))
+		b, _ := s.MarshalMsgpack()
+		var back String
+		if err := back.UnmarshalMsgpack(b); err != nil || back != s {
+			t.Errorf("round trip of a %d-byte string = %d bytes, %v", n, len(back.String), err)
+		}
+	}
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	values := append([]interface{}{Int64From(math.MaxInt64), Int64From(-200), NewInt64(0, false)}, siblingValues...)
+	for _, v := range values {
+		if !v.(interface{ IsSet() bool }).IsSet() {
+			// MessagePack has no unset state; unset encodes as nil.
+			continue
+		}
+		b, err := v.(msgpackEncoder).MarshalMsgpack()
+		if err != nil {
+			t.Errorf("%T.MarshalMsgpack(%+v): %v", v, v, err)
+			continue
+		}
+		p := newOf(v)
+		if err := p.(msgpackDecoder).UnmarshalMsgpack(b); err != nil || !sameValue(elem(p), v) {
+			t.Errorf("%T.UnmarshalMsgpack(%x) = %+v, %v, want %+v", v, b, elem(p), err, v)
+		}
+	}
+}
+
+func TestUnmarshalMsgpack(t *testing.T) {
+	var f Float64
+	for _, tt := range []struct {
+		data []byte
+		want float64
+	}{
+		{[]byte{0xca, 0x3f, 0xc0, 0x00, 0x00}, 1.5},
+		{[]byte{0xd0, 0x9c}, -100},
+		{[]byte{0x05}, 5},
+	} {
+		if err := f.UnmarshalMsgpack(tt.data); err != nil || f != Float64From(tt.want) {
+			t.Errorf("Float64.UnmarshalMsgpack(%x) = %+v, %v, want %v", tt.data, f, err, tt.want)
+		}
+	}
+
+	var u Uint64
+	if err := u.UnmarshalMsgpack([]byte{0xd1, 0x01, 0x00}); err != nil || u != Uint64From(256) {
+		t.Errorf("Uint64.UnmarshalMsgpack(int16 256) = %+v, %v", u, err)
+	}
+
+	var s String
+	if err := s.UnmarshalMsgpack([]byte{0xd9, 0x02, 'h', 'i'}); err != nil || s != StringFrom("hi") {
+		t.Errorf("String.UnmarshalMsgpack(str8) = %+v, %v", s, err)
+	}
+
+	var tm Time
+	if err := tm.UnmarshalMsgpack([]byte{0xd6, 0xff, 0, 0, 0, 1}); err != nil || tm != TimeFrom(time.Unix(1, 0).UTC()) {
+		t.Errorf("Time.UnmarshalMsgpack(timestamp 32) = %+v, %v", tm, err)
+	}
+}
+
+func TestUnmarshalMsgpackErrors(t *testing.T) {
+	tests := []struct {
+		p    msgpackDecoder
+		data []byte
+	}{
+		{new(Int64), nil},
+		{new(Int64), []byte{0xc0, 0x00}},
+		{new(Int64), []byte{0xcc}},
+		{new(Int64), []byte{0xcf, 0xff, 0, 0, 0, 0, 0, 0, 0}},
+		{new(Int64), []byte{0xa1, 'x'}},
+		{new(Uint64), []byte{0xff}},
+		{new(Float64), []byte{0xcb, 0x00}},
+		{new(Float64), []byte{0xc3}},
+		{new(Bool), []byte{0x01}},
+		{new(String), []byte{0xa2, 'a'}},
+		{new(String), []byte{0xa1, 0xff}},
+		{new(String), []byte{0xd9}},
+		{new(String), []byte{0x01}},
+		{new(Time), []byte{0xd6, 0x01, 0, 0, 0, 1}},
+		{new(Time), []byte{0xd7, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}},
+		{new(Int16), []byte{0xcd, 0xff, 0xff}},
+		{new(Uint32), []byte{0xff}},
+	}
+	for _, tt := range tests {
+		if err := tt.p.UnmarshalMsgpack(tt.data); err == nil {
+			t.Errorf("%T.UnmarshalMsgpack(%x) = %+v, want error", tt.p, tt.data, tt.p)
+		}
+		if v := tt.p.(interface{ IsValid() bool }); v.IsValid() {
+			t.Errorf("%T.UnmarshalMsgpack(%x) left a valid value", tt.p, tt.data)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package nullint64
+
+// The methods below support accumulating a nullable counter from partial
+// data before flushing it: the counter stays unset until the first
+// observation, so a flush can tell "no data" from a total of 0.
+
+// AddDelta adds d to this Int64, treating null and unset as 0, so the result
+// is always valid. Like Add it wraps on overflow.
+func (i *Int64) AddDelta(d int64) {
+	if !i.Valid {
+		i.Int64 = 0
+	}
+	i.SetValid(i.Int64 + d)
+}
+
+// Reset returns this Int64 to the unset zero value.
+func (i *Int64) Reset() {
+	*i = Int64{}
+}
+
+// Exchange stores the valid value n and returns the previous state, for
+// reading and restarting a counter in one step. It is named to leave Swap,
+// which exchanges two Int64s, unchanged.
+func (i *Int64) Exchange(n int64) Int64 {
+	old := *i
+	i.SetValid(n)
+	return old
+}
+
+// AddDelta adds d to the current value as Int64.AddDelta does, and returns
+// the new value.
+func (a *AtomicInt64) AddDelta(d int64) Int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.v.AddDelta(d)
+	return a.v
+}
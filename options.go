@@ -0,0 +1,46 @@
+package nullint64
+
+// Option sets the state of an Int64 built by New.
+type Option func(*Int64)
+
+// WithValue makes New build a valid Int64 holding n.
+func WithValue(n int64) Option {
+	return func(i *Int64) { i.SetValid(n) }
+}
+
+// Null makes New build an explicit null.
+func Null() Option {
+	return func(i *Int64) { i.SetNull() }
+}
+
+// Unset makes New build an unset Int64, which is also what it builds with
+// no options.
+func Unset() Option {
+	return func(i *Int64) { *i = Int64{} }
+}
+
+// New builds an Int64 by applying opts in order, so the last option wins,
+// starting from an unset Int64. It spells out the intended state where a
+// composite literal could silently leave Set false:
+//
+//	nullint64.New(nullint64.WithValue(42))
+//	nullint64.New(nullint64.Null())
+func New(opts ...Option) Int64 {
+	var i Int64
+	for _, opt := range opts {
+		opt(&i)
+	}
+	return i
+}
+
+// WithValue returns a copy of this Int64 holding the valid value n.
+func (i Int64) WithValue(n int64) Int64 {
+	i.SetValid(n)
+	return i
+}
+
+// WithNull returns a copy of this Int64 set to an explicit null.
+func (i Int64) WithNull() Int64 {
+	i.SetNull()
+	return i
+}
@@ -0,0 +1,39 @@
+package nullint64
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want Int64
+	}{
+		{"no options", nil, Int64{}},
+		{"value", []Option{WithValue(42)}, Int64{Int64: 42, Valid: true, Set: true}},
+		{"null", []Option{Null()}, Int64{Set: true}},
+		{"unset", []Option{WithValue(42), Unset()}, Int64{}},
+		{"last wins", []Option{Null(), WithValue(7)}, Int64{Int64: 7, Valid: true, Set: true}},
+		{"value then null", []Option{WithValue(7), Null()}, Int64{Set: true}},
+	}
+	for _, tt := range tests {
+		if got := New(tt.opts...); got != tt.want {
+			t.Errorf("%s: New = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithCopies(t *testing.T) {
+	orig := Int64From(1)
+	if got, want := orig.WithValue(2), Int64From(2); got != want {
+		t.Errorf("WithValue(2) = %+v, want %+v", got, want)
+	}
+	if got, want := orig.WithNull(), NewInt64(0, false); got != want {
+		t.Errorf("WithNull() = %+v, want %+v", got, want)
+	}
+	if orig != Int64From(1) {
+		t.Errorf("original modified to %+v", orig)
+	}
+	if got, want := (Int64{}).WithValue(3), Int64From(3); got != want {
+		t.Errorf("unset WithValue(3) = %+v, want %+v", got, want)
+	}
+}
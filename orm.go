@@ -0,0 +1,15 @@
+package nullint64
+
+// GormDataType implements the GormDataTypeInterface of gorm.io/gorm, so
+// AutoMigrate creates a nullable BIGINT column rather than failing to map
+// the struct. GORM passes the name through to the dialect unchanged, and
+// every dialect it ships accepts bigint. Queries need nothing further, as
+// GORM binds and scans through Value and Scan. It needs no import of the
+// gorm package.
+//
+// ent needs no hook either: Int64 is an ent ValueScanner, so a field
+// declared as field.Int64("n").GoType(nullint64.Int64{}).Optional() stores
+// as a nullable BIGINT.
+func (Int64) GormDataType() string {
+	return "bigint"
+}
@@ -0,0 +1,74 @@
+package nullint64
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// PadWidth is the width PaddedInt64 pads its JSON strings to.
+var PadWidth = 0
+
+// PadNull is the byte PaddedInt64 repeats PadWidth times to represent a
+// null. The default, a space, decodes back to null; '0' makes a null
+// indistinguishable from a zero.
+var PadNull byte = ' '
+
+// PaddedInt64 is an Int64 that marshals to JSON as a fixed-width string,
+// for legacy fixed-width integrations. Valid values are zero-padded to
+// PadWidth characters, sign included, as with fmt's %0*d; values longer
+// than PadWidth are emitted in full rather than truncated. Nulls are
+// PadWidth copies of PadNull.
+type PaddedInt64 struct {
+	Int64
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p PaddedInt64) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, PadWidth+2)
+	b = append(b, '"')
+	if !p.Valid {
+		b = append(b, bytes.Repeat([]byte{PadNull}, PadWidth)...)
+		return append(b, '"'), nil
+	}
+
+	digits := strconv.AppendInt(nil, p.Int64.Int64, 10)
+	if len(digits) < PadWidth {
+		zeros := bytes.Repeat([]byte{'0'}, PadWidth-len(digits))
+		if digits[0] == '-' {
+			b = append(b, '-')
+			digits = digits[1:]
+		}
+		b = append(b, zeros...)
+	}
+	b = append(b, digits...)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Padding is stripped before a
+// string is parsed, so both zero- and space-padded values are accepted.
+// Strings are always parsed in base 10, as MarshalJSON writes them,
+// whatever TextBase is; other JSON values are decoded as by Int64.
+func (p *PaddedInt64) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '"' {
+		return p.Int64.UnmarshalJSON(data)
+	}
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	p.Set = true
+	str = strings.TrimSpace(str)
+	if len(str) == 0 {
+		p.Int64.Int64, p.Valid = 0, false
+		return nil
+	}
+	var err error
+	p.Int64.Int64, err = strconv.ParseInt(str, 10, 64)
+	if errors.Is(err, strconv.ErrSyntax) {
+		err = &ParseError{Input: str, Offset: invalidOffset([]byte(str)), Err: err}
+	}
+	return p.finishJSON(err)
+}
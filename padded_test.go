@@ -0,0 +1,46 @@
+package nullint64
+
+import (
+	"testing"
+)
+
+func TestPaddedInt64JSON(t *testing.T) {
+	old := PadWidth
+	PadWidth = 6
+	t.Cleanup(func() { PadWidth = old })
+
+	tests := []struct {
+		in   PaddedInt64
+		want string
+	}{
+		{PaddedInt64{Int64From(42)}, `"000042"`},
+		{PaddedInt64{Int64From(-42)}, `"-00042"`},
+		{PaddedInt64{Int64From(1234567)}, `"1234567"`},
+		{PaddedInt64{NewInt64(0, false)}, `"      "`},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.MarshalJSON()
+		if err != nil || string(got) != tt.want {
+			t.Errorf("MarshalJSON(%+v) = %s, %v, want %s", tt.in, got, err, tt.want)
+			continue
+		}
+		var back PaddedInt64
+		if err := back.UnmarshalJSON(got); err != nil || back != tt.in {
+			t.Errorf("UnmarshalJSON(%s) = %+v, %v, want %+v", got, back, err, tt.in)
+		}
+	}
+}
+
+func TestPaddedInt64IgnoresTextBase(t *testing.T) {
+	old := TextBase
+	TextBase = 16
+	t.Cleanup(func() { TextBase = old })
+
+	var p PaddedInt64
+	if err := p.UnmarshalJSON([]byte(`"000010"`)); err != nil || p.Int64 != Int64From(10) {
+		t.Errorf("UnmarshalJSON with TextBase 16 = %+v, %v, want 10", p, err)
+	}
+	if err := p.UnmarshalJSON([]byte(`"00001f"`)); err == nil {
+		t.Errorf("UnmarshalJSON(%q) = %+v, want error", "00001f", p)
+	}
+}
@@ -0,0 +1,45 @@
+package nullint64
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// patchField is implemented by every nullable type in this package.
+type patchField interface {
+	IsSet() bool
+	driver.Valuer
+}
+
+// SetFields returns the fields of the struct v, or of the struct v points
+// to, that hold one of this package's nullable types with Set true, for
+// building partial UPDATE statements or JSON Merge Patch bodies. Keys are
+// field names as given by the struct tag key, such as "json" or "db",
+// falling back to the Go field name; fields tagged "-" are skipped and
+// fields of untagged embedded structs are promoted. Values are those the
+// field's Value method returns, so an explicit null maps to nil.
+func SetFields(v interface{}, key string) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("nullint64: SetFields requires a struct or a non-nil pointer to one")
+	}
+
+	m := make(map[string]interface{})
+	for _, f := range structFields(rv, key) {
+		p, ok := f.value.Interface().(patchField)
+		if !ok || !p.IsSet() {
+			continue
+		}
+		dv, err := p.Value()
+		if err != nil {
+			return nil, fmt.Errorf("nullint64: field %s: %w", f.name, err)
+		}
+		m[f.name] = dv
+	}
+	return m, nil
+}
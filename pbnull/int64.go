@@ -0,0 +1,57 @@
+// Package pbnull converts nullint64.Int64 to and from the protobuf
+// well-known wrapper types, and records which fields are Set in a
+// FieldMask for partial updates.
+//
+// A protobuf message can't tell an unset wrapper field from an explicit
+// null: both are a nil *wrapperspb.Int64Value. Use a FieldMask alongside
+// the message to carry that distinction.
+package pbnull
+
+import (
+	"sort"
+
+	"github.com/ccakes/nullint64"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// FromInt64Value converts v to an Int64. A nil v converts to an unset
+// Int64, since protobuf uses nil for an absent field.
+func FromInt64Value(v *wrapperspb.Int64Value) nullint64.Int64 {
+	if v == nil {
+		return nullint64.Int64{}
+	}
+	return nullint64.Int64From(v.GetValue())
+}
+
+// ToInt64Value converts i to a wrapper value, returning nil if i is null
+// or unset.
+func ToInt64Value(i nullint64.Int64) *wrapperspb.Int64Value {
+	if !i.Valid {
+		return nil
+	}
+	return wrapperspb.Int64(i.Int64)
+}
+
+// AddSetPaths appends to mask the path of every field in fields, keyed by
+// field path, whose Int64 is Set, so explicit nulls are cleared by the
+// update and unset fields are left alone. Paths are added in sorted order,
+// skipping any already in the mask. A nil mask has nowhere to record
+// paths, so AddSetPaths returns without doing anything.
+func AddSetPaths(mask *fieldmaskpb.FieldMask, fields map[string]nullint64.Int64) {
+	if mask == nil {
+		return
+	}
+	have := make(map[string]bool, len(mask.GetPaths()))
+	for _, p := range mask.GetPaths() {
+		have[p] = true
+	}
+	var paths []string
+	for p, i := range fields {
+		if i.Set && !have[p] {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	mask.Paths = append(mask.Paths, paths...)
+}
@@ -0,0 +1,8 @@
+module github.com/ccakes/nullint64/pgxnull
+
+go 1.25.0
+
+require (
+	github.com/ccakes/nullint64 v0.0.0-20261014160539-f823de60508b
+	github.com/jackc/pgx/v5 v5.11.0
+)
@@ -0,0 +1,57 @@
+// Package pgxnull adapts nullint64.Int64 to the native type system of
+// jackc/pgx v5, so values are encoded and decoded through pgx's int8 codec
+// rather than its database/sql compatibility path.
+package pgxnull
+
+import (
+	"strconv"
+
+	"github.com/ccakes/nullint64"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Int64 is a nullint64.Int64 implementing pgtype.Int64Scanner and
+// pgtype.Int64Valuer, and pgtype.TextScanner and pgtype.TextValuer for
+// text and varchar columns.
+type Int64 struct {
+	nullint64.Int64
+}
+
+// ScanInt64 implements pgtype.Int64Scanner.
+func (i *Int64) ScanInt64(v pgtype.Int8) error {
+	if !v.Valid {
+		return i.Int64.Scan(nil)
+	}
+	return i.Int64.Scan(v.Int64)
+}
+
+// Int64Value implements pgtype.Int64Valuer.
+func (i Int64) Int64Value() (pgtype.Int8, error) {
+	return pgtype.Int8{Int64: i.Int64.Int64, Valid: i.Valid}, nil
+}
+
+// ScanText implements pgtype.TextScanner, parsing the text as
+// nullint64.Int64.Scan parses strings. A SQL NULL scans as an explicit
+// null, as with ScanInt64.
+func (i *Int64) ScanText(v pgtype.Text) error {
+	if !v.Valid {
+		return i.Int64.Scan(nil)
+	}
+	return i.Int64.Scan(v.String)
+}
+
+// TextValue implements pgtype.TextValuer, encoding a valid value in
+// decimal.
+func (i Int64) TextValue() (pgtype.Text, error) {
+	if !i.Valid {
+		return pgtype.Text{}, nil
+	}
+	return pgtype.Text{String: strconv.FormatInt(i.Int64.Int64, 10), Valid: true}, nil
+}
+
+var (
+	_ pgtype.Int64Scanner = (*Int64)(nil)
+	_ pgtype.Int64Valuer  = Int64{}
+	_ pgtype.TextScanner  = (*Int64)(nil)
+	_ pgtype.TextValuer   = Int64{}
+)
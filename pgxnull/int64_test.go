@@ -0,0 +1,38 @@
+package pgxnull
+
+import (
+	"testing"
+
+	"github.com/ccakes/nullint64"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestInt8Codec(t *testing.T) {
+	m := pgtype.NewMap()
+	tests := []struct {
+		name string
+		in   Int64
+	}{
+		{"valid", Int64{nullint64.Int64From(42)}},
+		{"zero", Int64{nullint64.Int64From(0)}},
+		{"null", Int64{nullint64.NewInt64(0, false)}},
+	}
+	for _, tt := range tests {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(pgtype.Int8OID, format, tt.in, nil)
+			if err != nil {
+				t.Fatalf("%s: Encode: %v", tt.name, err)
+			}
+			if (buf == nil) == tt.in.Valid {
+				t.Errorf("%s: Encode = %v, want nil only for null", tt.name, buf)
+			}
+			var out Int64
+			if err := m.Scan(pgtype.Int8OID, format, buf, &out); err != nil {
+				t.Fatalf("%s: Scan: %v", tt.name, err)
+			}
+			if out.Int64 != tt.in.Int64 {
+				t.Errorf("%s, format %d: round trip = %+v, want %+v", tt.name, format, out.Int64, tt.in.Int64)
+			}
+		}
+	}
+}
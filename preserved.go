@@ -0,0 +1,47 @@
+package nullint64
+
+// PreservedInt64 is an Int64 that remembers the JSON token it was decoded
+// from and re-emits it verbatim, so pass-through proxies don't rewrite
+// inputs such as "007" or "0x1F" into canonical form. The token is dropped,
+// and MarshalJSON falls back to Int64's encoding, once the value is changed
+// through SetValid or SetNull; assigning the fields directly to a different
+// state has the same effect.
+type PreservedInt64 struct {
+	Int64
+	raw     []byte
+	decoded Int64
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p PreservedInt64) MarshalJSON() ([]byte, error) {
+	if p.raw != nil && p.Int64 == p.decoded {
+		return append([]byte(nil), p.raw...), nil
+	}
+	return p.Int64.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping a copy of data if it
+// decodes successfully.
+func (p *PreservedInt64) UnmarshalJSON(data []byte) error {
+	p.raw = nil
+	if err := p.Int64.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	p.raw = append([]byte(nil), data...)
+	p.decoded = p.Int64
+	return nil
+}
+
+// SetValid changes this PreservedInt64's value and discards the original
+// token.
+func (p *PreservedInt64) SetValid(n int64) {
+	p.raw = nil
+	p.Int64.SetValid(n)
+}
+
+// SetNull marks this PreservedInt64 as null and discards the original
+// token.
+func (p *PreservedInt64) SetNull() {
+	p.raw = nil
+	p.Int64.SetNull()
+}
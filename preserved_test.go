@@ -0,0 +1,63 @@
+package nullint64
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPreservedInt64(t *testing.T) {
+	tests := []struct {
+		data string
+		want Int64
+	}{
+		{`"007"`, Int64From(7)},
+		{`1`, Int64From(1)},
+		{`""`, NewInt64(0, false)},
+		{`null`, NewInt64(0, false)},
+	}
+	for _, tt := range tests {
+		var p PreservedInt64
+		if err := json.Unmarshal([]byte(tt.data), &p); err != nil || p.Int64 != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, %v, want %+v", tt.data, p.Int64, err, tt.want)
+			continue
+		}
+		out, err := json.Marshal(p)
+		if err != nil || string(out) != tt.data {
+			t.Errorf("Marshal after Unmarshal(%s) = %s, %v", tt.data, out, err)
+		}
+	}
+}
+
+func TestPreservedInt64Changed(t *testing.T) {
+	var p PreservedInt64
+	if err := p.UnmarshalJSON([]byte(`"007"`)); err != nil {
+		t.Fatal(err)
+	}
+	p.SetValid(7)
+	if out, _ := p.MarshalJSON(); string(out) != "7" {
+		t.Errorf("after SetValid = %s, want 7", out)
+	}
+
+	if err := p.UnmarshalJSON([]byte(`"007"`)); err != nil {
+		t.Fatal(err)
+	}
+	p.Int64.Int64 = 8
+	if out, _ := p.MarshalJSON(); string(out) != "8" {
+		t.Errorf("after field assignment = %s, want 8", out)
+	}
+
+	if err := p.UnmarshalJSON([]byte(`"007"`)); err != nil {
+		t.Fatal(err)
+	}
+	p.SetNull()
+	if out, _ := p.MarshalJSON(); string(out) != "null" {
+		t.Errorf("after SetNull = %s, want null", out)
+	}
+
+	if err := p.UnmarshalJSON([]byte(`"x"`)); err == nil {
+		t.Fatal("UnmarshalJSON(\"x\") succeeded")
+	}
+	if out, _ := p.MarshalJSON(); string(out) != "null" {
+		t.Errorf("after failed decode = %s, want null", out)
+	}
+}
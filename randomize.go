@@ -0,0 +1,14 @@
+package nullint64
+
+// Randomize implements the randomize.Randomizer interface of sqlboiler,
+// which fills models with test data. shouldBeNull yields an explicit
+// null; otherwise the value is the next value of nextInt, which sqlboiler
+// keeps distinct so unique columns don't collide. Either way the result is
+// Set.
+func (i *Int64) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		i.SetNull()
+		return
+	}
+	i.SetValid(nextInt())
+}
@@ -0,0 +1,363 @@
+package nullint64
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OnScanNull, if non-nil, is called whenever a scan receives a SQL NULL. It
+// is intended for wiring up data-quality metrics and must be set before any
+// concurrent use of the package.
+var OnScanNull func()
+
+// OnScanValue, if non-nil, is called with the value of every successful
+// non-null scan. Like OnScanNull it must be set before concurrent use.
+var OnScanValue func(int64)
+
+// ScanConfig collects the optional coercions applied when scanning a
+// database value into an Int64. The zero value matches the behavior of
+// Int64.Scan.
+type ScanConfig struct {
+	// BoolAsInt scans true as 1 and false as 0. By default bool values
+	// are rejected.
+	BoolAsInt bool
+
+	// RoundFloats rounds fractional float values to the nearest integer,
+	// with halves rounded away from zero. By default only floats with no
+	// fractional part are accepted.
+	RoundFloats bool
+
+	// TimeUnit is the unit time.Time values are converted to when scanned,
+	// measured from the Unix epoch. It defaults to time.Second, matching
+	// BIGINT epoch-seconds columns.
+	TimeUnit time.Duration
+
+	// ThousandsSeparator, if non-zero, is removed from string and byte
+	// values before parsing, so report-formatted text such as "1,234,567"
+	// scans as 1234567. The placement of separators is not checked.
+	ThousandsSeparator rune
+
+	// ByteOrder, if non-nil, makes []byte values decode as a raw int64 in
+	// that byte order, for BINARY(8) counter columns. Setting it turns off
+	// text parsing of byte values, so "12345678" is never mistaken for a
+	// number: any length other than 8 is an error. String values are still
+	// parsed as text.
+	ByteOrder binary.ByteOrder
+}
+
+// NewScanner returns a scan function applying the coercions in cfg. It
+// behaves like Int64.Scan for any input cfg doesn't enable a coercion for.
+func NewScanner(cfg ScanConfig) func(*Int64, interface{}) error {
+	return cfg.scan
+}
+
+func (c ScanConfig) scan(i *Int64, value interface{}) error {
+	if value == nil || isNilPointer(value) {
+		i.Int64, i.Valid, i.Set = 0, false, true
+		if OnScanNull != nil {
+			OnScanNull()
+		}
+		return nil
+	}
+
+	var (
+		n   int64
+		err error
+	)
+	switch v := value.(type) {
+	case int64:
+		n = v
+	case int:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int16:
+		n = int64(v)
+	case int8:
+		n = int64(v)
+	case uint:
+		n, err = uintToInt64(uint64(v))
+	case uint64:
+		n, err = uintToInt64(v)
+	case uint32:
+		n = int64(v)
+	case uint16:
+		n = int64(v)
+	case uint8:
+		n = int64(v)
+	case float64:
+		n, err = c.floatToInt64(v)
+	case float32:
+		n, err = c.floatToInt64(float64(v))
+	case []byte:
+		n, err = c.parseBytes(v)
+	case sql.RawBytes:
+		n, err = c.parseBytes(v)
+	case string:
+		n, err = c.parseString(v)
+	case bool:
+		if !c.BoolAsInt {
+			err = errUnsupported
+		} else if v {
+			n = 1
+		}
+	case time.Time:
+		n = unixIn(v, c.TimeUnit)
+	default:
+		// Named types of any kind handled above; reflection is kept off
+		// the path of the driver's own types.
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, err = uintToInt64(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			n, err = c.floatToInt64(rv.Float())
+		case reflect.String:
+			n, err = c.parseString(rv.String())
+		case reflect.Slice:
+			if rv.Type().Elem().Kind() != reflect.Uint8 {
+				err = errUnsupported
+				break
+			}
+			n, err = c.parseBytes(rv.Bytes())
+		default:
+			err = errUnsupported
+		}
+	}
+	if err != nil {
+		return i.scanError(value, conversionError(value, err))
+	}
+
+	i.Int64, i.Valid, i.Set = n, true, true
+	if OnScanValue != nil {
+		OnScanValue(i.Int64)
+	}
+	return nil
+}
+
+// uintToInt64 converts u, failing if it is above math.MaxInt64.
+func uintToInt64(u uint64) (int64, error) {
+	if u > math.MaxInt64 {
+		return 0, strconv.ErrRange
+	}
+	return int64(u), nil
+}
+
+// floatToInt64 converts f as the package-level floatToInt64 does, first
+// rounding it if RoundFloats is set.
+func (c ScanConfig) floatToInt64(f float64) (int64, error) {
+	if c.RoundFloats {
+		f = math.Round(f)
+	}
+	return floatToInt64(f)
+}
+
+// parseBytes parses a byte column value: as a raw binary int64 if
+// ByteOrder is set, and as text otherwise. It never retains b, which may
+// be a driver buffer reused on the next row.
+func (c ScanConfig) parseBytes(b []byte) (int64, error) {
+	if c.ByteOrder != nil {
+		if len(b) != 8 {
+			return 0, fmt.Errorf("%d-byte value, want 8 for binary int64", len(b))
+		}
+		return int64(c.ByteOrder.Uint64(b)), nil
+	}
+	return c.parseString(string(b))
+}
+
+// parseString parses a text column value. Surrounding whitespace is
+// ignored, and a 0x or 0X prefix after the sign selects hexadecimal, as
+// some drivers and ODBC bridges return; anything else must be decimal.
+func (c ScanConfig) parseString(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if c.ThousandsSeparator != 0 {
+		s = strings.ReplaceAll(s, string(c.ThousandsSeparator), "")
+	}
+
+	digits, neg := s, false
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		digits, neg = digits[1:], digits[0] == '-'
+	}
+	if len(digits) < 2 || digits[0] != '0' || (digits[1] != 'x' && digits[1] != 'X') {
+		n, err := strconv.ParseInt(s, 10, 64)
+		return n, numError(err)
+	}
+
+	u, err := strconv.ParseUint(digits[2:], 16, 64)
+	switch {
+	case err != nil:
+		return 0, numError(err)
+	case neg && u > 1<<63, !neg && u > math.MaxInt64:
+		return 0, strconv.ErrRange
+	case neg:
+		return -int64(u), nil
+	default:
+		return int64(u), nil
+	}
+}
+
+// numError unwraps a *strconv.NumError to the ErrSyntax or ErrRange it
+// carries, as the input is reported separately.
+func numError(err error) error {
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		return ne.Err
+	}
+	return err
+}
+
+// conversionError describes a failure to convert the driver value src.
+func conversionError(src interface{}, err error) error {
+	if err == errUnsupported {
+		return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type *int64", src)
+	}
+	rv := reflect.ValueOf(src)
+	if rv.Kind() == reflect.String || (rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8) {
+		return fmt.Errorf("converting driver.Value type %T (%q) to a int64: %w", src, rv.Convert(stringType).String(), err)
+	}
+	return fmt.Errorf("converting driver.Value type %T (%v) to a int64: %w", src, src, err)
+}
+
+var stringType = reflect.TypeOf("")
+
+// isNilPointer reports whether value is a typed nil pointer, such as
+// (*int64)(nil), which some drivers pass for NULL and which doesn't compare
+// equal to a nil interface.
+func isNilPointer(value interface{}) bool {
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// ScanError describes a failure to scan a database value into an Int64.
+type ScanError struct {
+	// GoType is the Go type of the value the driver supplied.
+	GoType string
+	// SQLType is the database type name of the source column, if the
+	// driver value reports one through a DatabaseTypeName() string
+	// method, and empty otherwise.
+	SQLType string
+	Err     error
+}
+
+func (e *ScanError) Error() string {
+	if e.SQLType != "" {
+		return fmt.Sprintf("nullint64: scanning %s (SQL type %s): %v", e.GoType, e.SQLType, e.Err)
+	}
+	return fmt.Sprintf("nullint64: scanning %s: %v", e.GoType, e.Err)
+}
+
+// Unwrap returns the underlying conversion error.
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// scanError leaves i set but null after failing to scan src, and returns
+// err wrapped in a *ScanError.
+func (i *Int64) scanError(src interface{}, err error) error {
+	i.Int64, i.Valid, i.Set = 0, false, true
+	return newScanError(src, err)
+}
+
+// newScanError wraps err, a failure to scan src, in a *ScanError.
+func newScanError(src interface{}, err error) *ScanError {
+	e := &ScanError{GoType: fmt.Sprintf("%T", src), Err: err}
+	if t, ok := src.(interface{ DatabaseTypeName() string }); ok {
+		e.SQLType = t.DatabaseTypeName()
+	}
+	return e
+}
+
+// errUnsupported reports a driver value of a type Scan can't convert.
+var errUnsupported = errors.New("unsupported type")
+
+// errNotFinite reports a NaN or infinite float.
+var errNotFinite = errors.New("value is NaN or infinite")
+
+// floatToInt64 converts f to an int64, failing rather than truncating if it
+// isn't an integer or is out of range.
+func floatToInt64(f float64) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, errNotFinite
+	}
+	// -2^63 is exactly representable as a float64, 2^63 is the first
+	// value past math.MaxInt64.
+	if f < math.MinInt64 || f >= -math.MinInt64 {
+		return 0, strconv.ErrRange
+	}
+	n := int64(f)
+	if float64(n) != f {
+		return 0, ErrFractional
+	}
+	return n, nil
+}
+
+// unixIn returns t as a count of unit since the Unix epoch, defaulting to
+// seconds when unit is not positive.
+func unixIn(t time.Time, unit time.Duration) int64 {
+	switch {
+	case unit <= 0 || unit == time.Second:
+		return t.Unix()
+	case unit > time.Second:
+		return t.Unix() / int64(unit/time.Second)
+	default:
+		return t.Unix()*int64(time.Second/unit) + int64(t.Nanosecond())/int64(unit)
+	}
+}
+
+// scannerFunc adapts a function to sql.Scanner.
+type scannerFunc func(value interface{}) error
+
+func (f scannerFunc) Scan(value interface{}) error {
+	return f(value)
+}
+
+// LoggingScanner returns a sql.Scanner that scans into dst as Int64.Scan
+// does, first logging the incoming type with log whenever it is something
+// other than int64 or NULL. It helps spot columns whose driver type has
+// drifted, for example after a schema migration.
+func LoggingScanner(dst *Int64, log func(format string, args ...interface{})) sql.Scanner {
+	return scannerFunc(func(value interface{}) error {
+		switch value.(type) {
+		case int64, nil:
+		default:
+			log("nullint64: scanning %T, expected int64", value)
+		}
+		return dst.Scan(value)
+	})
+}
+
+// NewCodeScanner returns a sql.Scanner for columns holding short string
+// codes that map to integer IDs. Each scanned code is looked up in m and
+// the ID stored in dst; an unknown code is an error and NULL scans as
+// null.
+func NewCodeScanner(dst *Int64, m map[string]int64) sql.Scanner {
+	return scannerFunc(func(value interface{}) error {
+		var code string
+		switch v := value.(type) {
+		case nil:
+			return dst.Scan(nil)
+		case string:
+			code = v
+		case []byte:
+			code = string(v)
+		case sql.RawBytes:
+			code = string(v)
+		default:
+			return dst.scanError(value, errors.New("not a string code"))
+		}
+		id, ok := m[code]
+		if !ok {
+			return dst.scanError(value, fmt.Errorf("unknown code %q", code))
+		}
+		return dst.Scan(id)
+	})
+}
@@ -0,0 +1,515 @@
+package nullint64
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type namedInt int16
+
+type namedFloat float32
+
+type namedString string
+
+type namedBytes []byte
+
+type rawBytesAlias sql.RawBytes
+
+// typedText is a driver value that reports its column type.
+type typedText string
+
+func (typedText) DatabaseTypeName() string { return "VARCHAR" }
+
+func TestScanNumericTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  int64
+	}{
+		{"int64", int64(-7), -7},
+		{"int", int(42), 42},
+		{"int32", int32(-32), -32},
+		{"int16", int16(16), 16},
+		{"int8", int8(-8), -8},
+		{"uint", uint(7), 7},
+		{"uint64", uint64(math.MaxInt64), math.MaxInt64},
+		{"uint32", uint32(32), 32},
+		{"uint16", uint16(16), 16},
+		{"uint8", uint8(8), 8},
+		{"float64", float64(3), 3},
+		{"float32", float32(-2), -2},
+		{"named int", namedInt(9), 9},
+		{"named float", namedFloat(5), 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i Int64
+			if err := i.Scan(tt.value); err != nil {
+				t.Fatalf("Scan(%v): %v", tt.value, err)
+			}
+			if !i.Valid || i.Int64 != tt.want {
+				t.Errorf("Scan(%v) = %+v, want %d", tt.value, i, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanNumericErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"uint64 overflow", uint64(math.MaxInt64) + 1},
+		{"fractional float64", 1.5},
+		{"NaN", math.NaN()},
+		{"infinite float32", float32(math.Inf(1))},
+	}
+	if strconv.IntSize == 64 {
+		// Built at run time, as the constant overflows a 32-bit uint.
+		maxUint := uint(0)
+		maxUint--
+		tests = append(tests, struct {
+			name  string
+			value interface{}
+		}{"uint overflow", maxUint})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i Int64
+			if err := i.Scan(tt.value); err == nil {
+				t.Errorf("Scan(%v) = %+v, want error", tt.value, i)
+			}
+		})
+	}
+}
+
+func TestScanNativeBounds(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  int64
+	}{
+		{int8(math.MinInt8), math.MinInt8},
+		{int8(math.MaxInt8), math.MaxInt8},
+		{int16(math.MinInt16), math.MinInt16},
+		{int16(math.MaxInt16), math.MaxInt16},
+		{int32(math.MinInt32), math.MinInt32},
+		{int32(math.MaxInt32), math.MaxInt32},
+		{uint8(math.MaxUint8), math.MaxUint8},
+		{uint16(math.MaxUint16), math.MaxUint16},
+		{uint32(math.MaxUint32), math.MaxUint32},
+		{uint(math.MaxInt), math.MaxInt},
+	}
+	for _, tt := range tests {
+		var i Int64
+		if err := i.Scan(tt.value); err != nil || !i.Valid || i.Int64 != tt.want {
+			t.Errorf("Scan(%T %v) = %+v, %v, want %d", tt.value, tt.value, i, err, tt.want)
+		}
+	}
+}
+
+func TestScanNativeAllocs(t *testing.T) {
+	for _, v := range []interface{}{int(42), int32(42), uint32(42), uint64(42)} {
+		var i Int64
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = i.Scan(v)
+		})
+		if allocs != 0 || i.Int64 != 42 {
+			t.Errorf("Scan(%T) allocated %v times per call, want 0", v, allocs)
+		}
+	}
+}
+
+func TestScanByteOrder(t *testing.T) {
+	var i Int64
+	le := NewScanner(ScanConfig{ByteOrder: binary.LittleEndian})
+	if err := le(&i, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); err != nil || i != Int64From(-1) {
+		t.Errorf("little-endian scan of all ones = %+v, %v, want -1", i, err)
+	}
+	if err := le(&i, []byte{0x39, 0x30, 0, 0, 0, 0, 0, 0}); err != nil || i != Int64From(12345) {
+		t.Errorf("little-endian scan = %+v, %v, want 12345", i, err)
+	}
+
+	scan := NewScanner(ScanConfig{ByteOrder: binary.BigEndian})
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{"binary", []byte{0, 0, 0, 0, 0, 0, 0x30, 0x39}, 12345, false},
+		{"digit bytes", []byte("12345678"), 0x3132333435363738, false},
+		{"short", []byte("1234"), 0, true},
+		{"long", []byte("123456789"), 0, true},
+		{"string", "1234", 1234, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i Int64
+			err := scan(&i, tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("scan(%v) = %+v, want error", tt.value, i)
+				}
+				return
+			}
+			if err != nil || !i.Valid || i.Int64 != tt.want {
+				t.Errorf("scan(%v) = %+v, %v, want %d", tt.value, i, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{"bytes", []byte("123"), 123, false},
+		{"raw bytes", sql.RawBytes("-45"), -45, false},
+		{"padded", []byte(" 7 "), 7, false},
+		{"hex", sql.RawBytes("0x1f"), 31, false},
+		{"empty", []byte{}, 0, true},
+		{"garbage", sql.RawBytes("12a"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i Int64
+			err := i.Scan(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && (!i.Valid || i.Int64 != tt.want) {
+				t.Errorf("Scan(%q) = %+v, want %d", tt.value, i, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanBytesNotRetained(t *testing.T) {
+	buf := sql.RawBytes("123")
+	var i Int64
+	if err := i.Scan(buf); err != nil {
+		t.Fatal(err)
+	}
+	// Drivers reuse the buffer for the next row.
+	copy(buf, "999")
+	if i.Int64 != 123 {
+		t.Errorf("Int64 = %d after buffer reuse, want 123", i.Int64)
+	}
+}
+
+func TestScanConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ScanConfig
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{"bool rejected", ScanConfig{}, true, 0, true},
+		{"bool true", ScanConfig{BoolAsInt: true}, true, 1, false},
+		{"bool false", ScanConfig{BoolAsInt: true}, false, 0, false},
+		{"fraction rejected", ScanConfig{}, 2.5, 0, true},
+		{"round half away", ScanConfig{RoundFloats: true}, 2.5, 3, false},
+		{"round negative", ScanConfig{RoundFloats: true}, float32(-2.5), -3, false},
+		{"round down", ScanConfig{RoundFloats: true}, 2.4, 2, false},
+		{"unaffected", ScanConfig{BoolAsInt: true, RoundFloats: true}, "12", 12, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i Int64
+			err := NewScanner(tt.cfg)(&i, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("scan(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && (!i.Valid || i.Int64 != tt.want) {
+				t.Errorf("scan(%v) = %+v, want %d", tt.value, i, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanConfigZeroMatchesScan(t *testing.T) {
+	scan := NewScanner(ScanConfig{})
+	for _, v := range []interface{}{nil, int64(4), "5", []byte("6"), 7.0, true, 1.5, "x"} {
+		var got, want Int64
+		gotErr := scan(&got, v)
+		wantErr := want.Scan(v)
+		if got != want || (gotErr == nil) != (wantErr == nil) {
+			t.Errorf("scan(%v) = %+v, %v; Scan = %+v, %v", v, got, gotErr, want, wantErr)
+		}
+	}
+}
+
+func TestSafeScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    Int64
+		wantErr bool
+	}{
+		{"valid", "12", Int64From(12), false},
+		{"null", nil, NewInt64(0, false), false},
+		{"syntax error", "12x", Int64{}, true},
+		{"out of range", uint64(math.MaxUint64), Int64{}, true},
+		{"unsupported", struct{}{}, Int64{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := Int64From(99)
+			err := i.SafeScan(tt.value)
+			if (err != nil) != tt.wantErr || i != tt.want {
+				t.Errorf("SafeScan(%v) = %+v, %v, want %+v", tt.value, i, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanTime(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 123456789, time.UTC)
+	tests := []struct {
+		unit time.Duration
+		want int64
+	}{
+		{0, ts.Unix()},
+		{time.Second, ts.Unix()},
+		{time.Millisecond, ts.UnixNano() / 1e6},
+		{time.Microsecond, ts.UnixNano() / 1e3},
+		{time.Nanosecond, ts.UnixNano()},
+		{time.Minute, ts.Unix() / 60},
+	}
+	for _, tt := range tests {
+		var i Int64
+		if err := NewScanner(ScanConfig{TimeUnit: tt.unit})(&i, ts); err != nil || !i.Valid || i.Int64 != tt.want {
+			t.Errorf("scan with TimeUnit %v = %+v, %v, want %d", tt.unit, i, err, tt.want)
+		}
+	}
+
+	var i Int64
+	if err := i.Scan(ts); err != nil || i.Int64 != ts.Unix() {
+		t.Errorf("Scan(time) = %+v, %v, want %d", i, err, ts.Unix())
+	}
+}
+
+func TestScanHooks(t *testing.T) {
+	var nulls int
+	var values []int64
+	oldNull, oldValue := OnScanNull, OnScanValue
+	OnScanNull = func() { nulls++ }
+	OnScanValue = func(n int64) { values = append(values, n) }
+	t.Cleanup(func() { OnScanNull, OnScanValue = oldNull, oldValue })
+
+	var i Int64
+	for _, v := range []interface{}{nil, int64(1), "x", (*int64)(nil), "2"} {
+		_ = i.Scan(v)
+	}
+	if nulls != 2 {
+		t.Errorf("OnScanNull called %d times, want 2", nulls)
+	}
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Errorf("OnScanValue called with %v, want [1 2]", values)
+	}
+}
+
+func TestScanNamedTextTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{"named string", namedString("17"), 17, false},
+		{"named bytes", namedBytes("-4"), -4, false},
+		{"raw bytes subtype", rawBytesAlias("0x10"), 16, false},
+		{"bad named string", namedString("x"), 0, true},
+		{"int slice", []int{1}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var i Int64
+			err := i.Scan(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && (!i.Valid || i.Int64 != tt.want) {
+				t.Errorf("Scan(%v) = %+v, want %d", tt.value, i, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanFloatTruncation(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  error
+	}{
+		{"fraction", 2.5, ErrFractional},
+		{"small fraction", float32(0.25), ErrFractional},
+		{"too large", 9.3e18, strconv.ErrRange},
+		{"too small", -9.3e18, strconv.ErrRange},
+		{"two to the 63", math.Pow(2, 63), strconv.ErrRange},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := i.Scan(tt.value)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: Scan(%v) error = %v, want %v", tt.name, tt.value, err, tt.want)
+		}
+		if i.Valid {
+			t.Errorf("%s: Scan(%v) = %+v, want invalid", tt.name, tt.value, i)
+		}
+	}
+
+	var i Int64
+	if err := i.Scan(float64(math.MinInt64)); err != nil || i.Int64 != math.MinInt64 {
+		t.Errorf("Scan(MinInt64 as float) = %+v, %v", i, err)
+	}
+}
+
+func TestScanNotFinite(t *testing.T) {
+	for _, cfg := range []ScanConfig{{}, {RoundFloats: true}} {
+		for _, v := range []interface{}{math.NaN(), math.Inf(1), float32(math.Inf(-1))} {
+			var i Int64
+			err := NewScanner(cfg)(&i, v)
+			if !errors.Is(err, errNotFinite) || i.Valid {
+				t.Errorf("scan(%v) with %+v = %+v, %v, want errNotFinite", v, cfg, i, err)
+			}
+		}
+	}
+}
+
+func TestScanNilPointer(t *testing.T) {
+	for _, v := range []interface{}{(*int64)(nil), (*string)(nil), (*[]byte)(nil), (*Int64)(nil)} {
+		i := Int64From(5)
+		if err := i.Scan(v); err != nil || i != NewInt64(0, false) {
+			t.Errorf("Scan(%T nil) = %+v, %v, want explicit null", v, i, err)
+		}
+	}
+
+	n := int64(3)
+	var i Int64
+	if err := i.Scan(&n); err == nil {
+		t.Errorf("Scan(non-nil pointer) = %+v, want unsupported error", i)
+	}
+}
+
+func TestLoggingScanner(t *testing.T) {
+	var logged []string
+	log := func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+	var i Int64
+	s := LoggingScanner(&i, log)
+	for _, v := range []interface{}{int64(1), nil, "2", []byte("3")} {
+		if err := s.Scan(v); err != nil {
+			t.Fatalf("Scan(%v): %v", v, err)
+		}
+	}
+	want := []string{
+		"nullint64: scanning string, expected int64",
+		"nullint64: scanning []uint8, expected int64",
+	}
+	if !reflect.DeepEqual(logged, want) {
+		t.Errorf("logged %q, want %q", logged, want)
+	}
+	if i != Int64From(3) {
+		t.Errorf("dst = %+v, want 3", i)
+	}
+}
+
+func TestCodeScanner(t *testing.T) {
+	codes := map[string]int64{"active": 1, "closed": 2}
+	tests := []struct {
+		value   interface{}
+		want    Int64
+		wantErr bool
+	}{
+		{"active", Int64From(1), false},
+		{[]byte("closed"), Int64From(2), false},
+		{sql.RawBytes("active"), Int64From(1), false},
+		{nil, NewInt64(0, false), false},
+		{"pending", NewInt64(0, false), true},
+		{int64(1), NewInt64(0, false), true},
+	}
+	for _, tt := range tests {
+		i := Int64From(9)
+		err := NewCodeScanner(&i, codes).Scan(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Scan(%v) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && i != tt.want {
+			t.Errorf("Scan(%v) = %+v, want %+v", tt.value, i, tt.want)
+		}
+		var se *ScanError
+		if tt.wantErr && !errors.As(err, &se) {
+			t.Errorf("Scan(%v) error %T is not a *ScanError", tt.value, err)
+		}
+	}
+}
+
+func TestScanError(t *testing.T) {
+	tests := []struct {
+		value   interface{}
+		goType  string
+		sqlType string
+		msg     string
+	}{
+		{"x", "string", "", `nullint64: scanning string: converting driver.Value type string ("x") to a int64: invalid syntax`},
+		{typedText("x"), "nullint64.typedText", "VARCHAR", `nullint64: scanning nullint64.typedText (SQL type VARCHAR): converting driver.Value type nullint64.typedText ("x") to a int64: invalid syntax`},
+		{struct{}{}, "struct {}", "", "nullint64: scanning struct {}: unsupported Scan, storing driver.Value type struct {} into type *int64"},
+	}
+	for _, tt := range tests {
+		i := Int64From(1)
+		err := i.Scan(tt.value)
+		var se *ScanError
+		if !errors.As(err, &se) {
+			t.Errorf("Scan(%v) error = %v, want *ScanError", tt.value, err)
+			continue
+		}
+		if se.GoType != tt.goType || se.SQLType != tt.sqlType || se.Error() != tt.msg {
+			t.Errorf("Scan(%v) error = %+v, %q", tt.value, se, se.Error())
+		}
+		if i != NewInt64(0, false) {
+			t.Errorf("Scan(%v) left %+v, want explicit null", tt.value, i)
+		}
+	}
+}
+
+func TestScanThousandsSeparator(t *testing.T) {
+	tests := []struct {
+		sep     rune
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{',', "1,234,567", 1234567, false},
+		{',', []byte("-12,000"), -12000, false},
+		{',', " 1,2,3 ", 123, false},
+		{'.', "1.234", 1234, false},
+		{' ', "1 000", 1000, false},
+		{0, "1,234", 0, true},
+		{',', "1,2x", 0, true},
+	}
+	for _, tt := range tests {
+		var i Int64
+		err := NewScanner(ScanConfig{ThousandsSeparator: tt.sep})(&i, tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("scan(%q) with separator %q error = %v, wantErr %v", tt.value, tt.sep, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && i != Int64From(tt.want) {
+			t.Errorf("scan(%q) with separator %q = %+v, want %d", tt.value, tt.sep, i, tt.want)
+		}
+	}
+}
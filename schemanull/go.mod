@@ -0,0 +1,15 @@
+module github.com/ccakes/nullint64/schemanull
+
+go 1.25.0
+
+require (
+	github.com/ccakes/nullint64 v0.0.0-20261014160539-f823de60508b
+	github.com/invopop/jsonschema v0.14.0
+)
+
+require (
+	github.com/bahlo/generic-list-go v0.2.0 // indirect
+	github.com/buger/jsonparser v1.1.2 // indirect
+	github.com/pb33f/ordered-map/v2 v2.3.1 // indirect
+	go.yaml.in/yaml/v4 v4.0.0-rc.2 // indirect
+)
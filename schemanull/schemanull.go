@@ -0,0 +1,51 @@
+// Package schemanull describes the nullint64 types to invopop/jsonschema,
+// so generated schemas show the JSON they actually encode to, such as
+// {"type":["integer","null"],"format":"int64"}, rather than an object with
+// Int64, Valid and Set properties. Install it on a Reflector:
+//
+//	r := &jsonschema.Reflector{Mapper: schemanull.Mapper}
+//
+// swaggo/swag can't call a mapper; replace the types in its .swaggo
+// overrides file instead, which gives the same types without null:
+//
+//	replace github.com/ccakes/nullint64.Int64 int64
+package schemanull
+
+import (
+	"reflect"
+
+	"github.com/ccakes/nullint64"
+	"github.com/invopop/jsonschema"
+)
+
+// types maps each nullint64 type to the JSON Schema type and format of
+// its non-null values.
+var types = map[reflect.Type][2]string{
+	reflect.TypeOf(nullint64.Int64{}):       {"integer", "int64"},
+	reflect.TypeOf(nullint64.Int32{}):       {"integer", "int32"},
+	reflect.TypeOf(nullint64.Int16{}):       {"integer", "int16"},
+	reflect.TypeOf(nullint64.Int8{}):        {"integer", "int8"},
+	reflect.TypeOf(nullint64.Uint32{}):      {"integer", "uint32"},
+	reflect.TypeOf(nullint64.Uint64{}):      {"integer", "uint64"},
+	reflect.TypeOf(nullint64.Float64{}):     {"number", "double"},
+	reflect.TypeOf(nullint64.String{}):      {"string", ""},
+	reflect.TypeOf(nullint64.Bool{}):        {"boolean", ""},
+	reflect.TypeOf(nullint64.Time{}):        {"string", "date-time"},
+	reflect.TypeOf(nullint64.Int64String{}): {"string", "int64"},
+}
+
+// Mapper is a jsonschema.Reflector Mapper returning the schema of the
+// nullint64 types, and nil for any other type so the Reflector handles it
+// as usual.
+func Mapper(t reflect.Type) *jsonschema.Schema {
+	tf, ok := types[t]
+	if !ok {
+		return nil
+	}
+	// Schema.Type holds a single type, so the ["type", "null"] array goes
+	// in Extras, which are merged into the encoded schema.
+	return &jsonschema.Schema{
+		Format: tf[1],
+		Extras: map[string]any{"type": []string{tf[0], "null"}},
+	}
+}
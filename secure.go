@@ -0,0 +1,13 @@
+package nullint64
+
+import "runtime"
+
+// SecureZero overwrites this Int64's value and clears Valid and Set, for
+// fields holding sensitive values that should be scrubbed after use. The
+// stores go through i and are kept live with runtime.KeepAlive so the
+// compiler cannot discard them as dead. Copies made earlier, by passing
+// the Int64 by value or marshaling it, are not affected.
+func (i *Int64) SecureZero() {
+	*i = Int64{}
+	runtime.KeepAlive(i)
+}
@@ -0,0 +1,19 @@
+package nullint64
+
+import (
+	"testing"
+)
+
+func TestSecureZero(t *testing.T) {
+	for _, in := range []Int64{Int64From(123456789), NewInt64(0, false), {Int64: 7}} {
+		i := in
+		copied := i
+		i.SecureZero()
+		if i != (Int64{}) {
+			t.Errorf("SecureZero(%+v) left %+v", in, i)
+		}
+		if copied != in {
+			t.Errorf("SecureZero modified an earlier copy: %+v", copied)
+		}
+	}
+}
@@ -0,0 +1,823 @@
+package nullint64
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+	"fmt"
+	"math"
+)
+
+// The narrower integer types below share Int64's Valid/Set semantics and
+// its parsing, scanning and marshaling rules, including the package-level
+// options: each decodes through an Int64 and then checks the result fits,
+// so a value out of range for the type is an error rather than being
+// truncated.
+
+// rangeError reports a decoded value too wide for the named type.
+func rangeError(n int64, typ string) error {
+	return fmt.Errorf("nullint64: %d is out of range for %s", n, typ)
+}
+
+// Int32 is a nullable int32, for 32-bit integer columns.
+type Int32 struct {
+	Int32 int32
+	Valid bool
+	Set   bool
+}
+
+// NewInt32 creates a new Int32.
+func NewInt32(v int32, valid bool) Int32 {
+	return Int32{Int32: v, Valid: valid, Set: true}
+}
+
+// Int32From creates a new Int32 that will always be valid.
+func Int32From(v int32) Int32 {
+	return NewInt32(v, true)
+}
+
+// Int32FromPtr creates a new Int32 that will be null if v is nil.
+func Int32FromPtr(v *int32) Int32 {
+	if v == nil {
+		return NewInt32(0, false)
+	}
+	return NewInt32(*v, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (i Int32) IsValid() bool {
+	return i.Set && i.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (i Int32) IsSet() bool {
+	return i.Set
+}
+
+// Int64 widens this Int32 to an Int64 with the same state.
+func (i Int32) Int64() Int64 {
+	return Int64{Int64: int64(i.Int32), Valid: i.Valid, Set: i.Set}
+}
+
+// narrow sets i from w, decoded with error err, checking that w fits.
+func (i *Int32) narrow(w Int64, err error) error {
+	if err == nil && w.Valid && (w.Int64 < math.MinInt32 || w.Int64 > math.MaxInt32) {
+		err = rangeError(w.Int64, "Int32")
+		w = Int64{Set: w.Set}
+	}
+	i.Int32, i.Valid, i.Set = int32(w.Int64), w.Valid, w.Set
+	return err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Int32) UnmarshalJSON(data []byte) error {
+	var w Int64
+	err := w.UnmarshalJSON(data)
+	return i.narrow(w, err)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Int32) UnmarshalText(text []byte) error {
+	var w Int64
+	err := w.UnmarshalText(text)
+	return i.narrow(w, err)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i Int32) MarshalJSON() ([]byte, error) {
+	return i.Int64().MarshalJSON()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i Int32) MarshalText() ([]byte, error) {
+	return i.Int64().MarshalText()
+}
+
+// SetValid changes this Int32's value and also sets it to be non-null.
+func (i *Int32) SetValid(v int32) {
+	i.Int32, i.Valid, i.Set = v, true, true
+}
+
+// SetNull sets this Int32 to an explicit null.
+func (i *Int32) SetNull() {
+	i.Int32, i.Valid, i.Set = 0, false, true
+}
+
+// Ptr returns a pointer to this Int32's value, or a nil pointer if this Int32 is null.
+func (i Int32) Ptr() *int32 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int32
+}
+
+// IsZero returns true for invalid Int32's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (i Int32) IsZero() bool {
+	return isZero(i.Valid, i.Set)
+}
+
+// Scan implements the Scanner interface.
+func (i *Int32) Scan(value interface{}) error {
+	var w Int64
+	err := w.Scan(value)
+	return i.narrow(w, err)
+}
+
+// Value implements the driver Valuer interface.
+func (i Int32) Value() (driver.Value, error) {
+	return i.Int64().Value()
+}
+
+// Int16 is a nullable int16, for 16-bit integer columns.
+type Int16 struct {
+	Int16 int16
+	Valid bool
+	Set   bool
+}
+
+// NewInt16 creates a new Int16.
+func NewInt16(v int16, valid bool) Int16 {
+	return Int16{Int16: v, Valid: valid, Set: true}
+}
+
+// Int16From creates a new Int16 that will always be valid.
+func Int16From(v int16) Int16 {
+	return NewInt16(v, true)
+}
+
+// Int16FromPtr creates a new Int16 that will be null if v is nil.
+func Int16FromPtr(v *int16) Int16 {
+	if v == nil {
+		return NewInt16(0, false)
+	}
+	return NewInt16(*v, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (i Int16) IsValid() bool {
+	return i.Set && i.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (i Int16) IsSet() bool {
+	return i.Set
+}
+
+// Int64 widens this Int16 to an Int64 with the same state.
+func (i Int16) Int64() Int64 {
+	return Int64{Int64: int64(i.Int16), Valid: i.Valid, Set: i.Set}
+}
+
+// narrow sets i from w, decoded with error err, checking that w fits.
+func (i *Int16) narrow(w Int64, err error) error {
+	if err == nil && w.Valid && (w.Int64 < math.MinInt16 || w.Int64 > math.MaxInt16) {
+		err = rangeError(w.Int64, "Int16")
+		w = Int64{Set: w.Set}
+	}
+	i.Int16, i.Valid, i.Set = int16(w.Int64), w.Valid, w.Set
+	return err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Int16) UnmarshalJSON(data []byte) error {
+	var w Int64
+	err := w.UnmarshalJSON(data)
+	return i.narrow(w, err)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Int16) UnmarshalText(text []byte) error {
+	var w Int64
+	err := w.UnmarshalText(text)
+	return i.narrow(w, err)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i Int16) MarshalJSON() ([]byte, error) {
+	return i.Int64().MarshalJSON()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i Int16) MarshalText() ([]byte, error) {
+	return i.Int64().MarshalText()
+}
+
+// SetValid changes this Int16's value and also sets it to be non-null.
+func (i *Int16) SetValid(v int16) {
+	i.Int16, i.Valid, i.Set = v, true, true
+}
+
+// SetNull sets this Int16 to an explicit null.
+func (i *Int16) SetNull() {
+	i.Int16, i.Valid, i.Set = 0, false, true
+}
+
+// Ptr returns a pointer to this Int16's value, or a nil pointer if this Int16 is null.
+func (i Int16) Ptr() *int16 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int16
+}
+
+// IsZero returns true for invalid Int16's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (i Int16) IsZero() bool {
+	return isZero(i.Valid, i.Set)
+}
+
+// Scan implements the Scanner interface.
+func (i *Int16) Scan(value interface{}) error {
+	var w Int64
+	err := w.Scan(value)
+	return i.narrow(w, err)
+}
+
+// Value implements the driver Valuer interface.
+func (i Int16) Value() (driver.Value, error) {
+	return i.Int64().Value()
+}
+
+// Int8 is a nullable int8, for 8-bit integer columns.
+type Int8 struct {
+	Int8  int8
+	Valid bool
+	Set   bool
+}
+
+// NewInt8 creates a new Int8.
+func NewInt8(v int8, valid bool) Int8 {
+	return Int8{Int8: v, Valid: valid, Set: true}
+}
+
+// Int8From creates a new Int8 that will always be valid.
+func Int8From(v int8) Int8 {
+	return NewInt8(v, true)
+}
+
+// Int8FromPtr creates a new Int8 that will be null if v is nil.
+func Int8FromPtr(v *int8) Int8 {
+	if v == nil {
+		return NewInt8(0, false)
+	}
+	return NewInt8(*v, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (i Int8) IsValid() bool {
+	return i.Set && i.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (i Int8) IsSet() bool {
+	return i.Set
+}
+
+// Int64 widens this Int8 to an Int64 with the same state.
+func (i Int8) Int64() Int64 {
+	return Int64{Int64: int64(i.Int8), Valid: i.Valid, Set: i.Set}
+}
+
+// narrow sets i from w, decoded with error err, checking that w fits.
+func (i *Int8) narrow(w Int64, err error) error {
+	if err == nil && w.Valid && (w.Int64 < math.MinInt8 || w.Int64 > math.MaxInt8) {
+		err = rangeError(w.Int64, "Int8")
+		w = Int64{Set: w.Set}
+	}
+	i.Int8, i.Valid, i.Set = int8(w.Int64), w.Valid, w.Set
+	return err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Int8) UnmarshalJSON(data []byte) error {
+	var w Int64
+	err := w.UnmarshalJSON(data)
+	return i.narrow(w, err)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Int8) UnmarshalText(text []byte) error {
+	var w Int64
+	err := w.UnmarshalText(text)
+	return i.narrow(w, err)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i Int8) MarshalJSON() ([]byte, error) {
+	return i.Int64().MarshalJSON()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i Int8) MarshalText() ([]byte, error) {
+	return i.Int64().MarshalText()
+}
+
+// SetValid changes this Int8's value and also sets it to be non-null.
+func (i *Int8) SetValid(v int8) {
+	i.Int8, i.Valid, i.Set = v, true, true
+}
+
+// SetNull sets this Int8 to an explicit null.
+func (i *Int8) SetNull() {
+	i.Int8, i.Valid, i.Set = 0, false, true
+}
+
+// Ptr returns a pointer to this Int8's value, or a nil pointer if this Int8 is null.
+func (i Int8) Ptr() *int8 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int8
+}
+
+// IsZero returns true for invalid Int8's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (i Int8) IsZero() bool {
+	return isZero(i.Valid, i.Set)
+}
+
+// Scan implements the Scanner interface.
+func (i *Int8) Scan(value interface{}) error {
+	var w Int64
+	err := w.Scan(value)
+	return i.narrow(w, err)
+}
+
+// Value implements the driver Valuer interface.
+func (i Int8) Value() (driver.Value, error) {
+	return i.Int64().Value()
+}
+
+// Uint32 is a nullable uint32, for 32-bit unsigned integer columns.
+type Uint32 struct {
+	Uint32 uint32
+	Valid  bool
+	Set    bool
+}
+
+// NewUint32 creates a new Uint32.
+func NewUint32(v uint32, valid bool) Uint32 {
+	return Uint32{Uint32: v, Valid: valid, Set: true}
+}
+
+// Uint32From creates a new Uint32 that will always be valid.
+func Uint32From(v uint32) Uint32 {
+	return NewUint32(v, true)
+}
+
+// Uint32FromPtr creates a new Uint32 that will be null if v is nil.
+func Uint32FromPtr(v *uint32) Uint32 {
+	if v == nil {
+		return NewUint32(0, false)
+	}
+	return NewUint32(*v, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (u Uint32) IsValid() bool {
+	return u.Set && u.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (u Uint32) IsSet() bool {
+	return u.Set
+}
+
+// Int64 widens this Uint32 to an Int64 with the same state.
+func (u Uint32) Int64() Int64 {
+	return Int64{Int64: int64(u.Uint32), Valid: u.Valid, Set: u.Set}
+}
+
+// narrow sets u from w, decoded with error err, checking that w fits.
+func (u *Uint32) narrow(w Int64, err error) error {
+	if err == nil && w.Valid && (w.Int64 < 0 || w.Int64 > math.MaxUint32) {
+		err = rangeError(w.Int64, "Uint32")
+		w = Int64{Set: w.Set}
+	}
+	u.Uint32, u.Valid, u.Set = uint32(w.Int64), w.Valid, w.Set
+	return err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *Uint32) UnmarshalJSON(data []byte) error {
+	var w Int64
+	err := w.UnmarshalJSON(data)
+	return u.narrow(w, err)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *Uint32) UnmarshalText(text []byte) error {
+	var w Int64
+	err := w.UnmarshalText(text)
+	return u.narrow(w, err)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u Uint32) MarshalJSON() ([]byte, error) {
+	return u.Int64().MarshalJSON()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u Uint32) MarshalText() ([]byte, error) {
+	return u.Int64().MarshalText()
+}
+
+// SetValid changes this Uint32's value and also sets it to be non-null.
+func (u *Uint32) SetValid(v uint32) {
+	u.Uint32, u.Valid, u.Set = v, true, true
+}
+
+// SetNull sets this Uint32 to an explicit null.
+func (u *Uint32) SetNull() {
+	u.Uint32, u.Valid, u.Set = 0, false, true
+}
+
+// Ptr returns a pointer to this Uint32's value, or a nil pointer if this Uint32 is null.
+func (u Uint32) Ptr() *uint32 {
+	if !u.Valid {
+		return nil
+	}
+	return &u.Uint32
+}
+
+// IsZero returns true for invalid Uint32's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (u Uint32) IsZero() bool {
+	return isZero(u.Valid, u.Set)
+}
+
+// Scan implements the Scanner interface.
+func (u *Uint32) Scan(value interface{}) error {
+	var w Int64
+	err := w.Scan(value)
+	return u.narrow(w, err)
+}
+
+// Value implements the driver Valuer interface.
+func (u Uint32) Value() (driver.Value, error) {
+	return u.Int64().Value()
+}
+
+// The narrower types support every other encoding Int64 does in the same
+// way, encoding as their Int64 and range-checking what they decode, so
+// they are interchangeable with Int64 on the wire.
+
+// AppendText implements encoding.TextAppender.
+func (i Int32) AppendText(b []byte) ([]byte, error) {
+	return i.Int64().AppendText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (i Int32) MarshalBinary() ([]byte, error) {
+	return i.Int64().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int32) UnmarshalBinary(data []byte) error {
+	var w Int64
+	err := w.UnmarshalBinary(data)
+	return i.narrow(w, err)
+}
+
+// GobEncode implements gob.GobEncoder.
+func (i Int32) GobEncode() ([]byte, error) {
+	return i.Int64().GobEncode()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (i *Int32) GobDecode(data []byte) error {
+	var w Int64
+	err := w.GobDecode(data)
+	return i.narrow(w, err)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i Int32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.Int64().MarshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *Int32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var w Int64
+	err := w.UnmarshalXML(d, start)
+	return i.narrow(w, err)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i Int32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.Int64().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *Int32) UnmarshalXMLAttr(attr xml.Attr) error {
+	var w Int64
+	err := w.UnmarshalXMLAttr(attr)
+	return i.narrow(w, err)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Int32) MarshalYAML() (interface{}, error) {
+	return i.Int64().MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var w Int64
+	err := w.UnmarshalYAML(unmarshal)
+	return i.narrow(w, err)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i Int32) MarshalCBOR() ([]byte, error) {
+	return i.Int64().MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *Int32) UnmarshalCBOR(data []byte) error {
+	var w Int64
+	err := w.UnmarshalCBOR(data)
+	return i.narrow(w, err)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+func (i Int32) MarshalMsgpack() ([]byte, error) {
+	return i.Int64().MarshalMsgpack()
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+func (i *Int32) UnmarshalMsgpack(data []byte) error {
+	var w Int64
+	err := w.UnmarshalMsgpack(data)
+	return i.narrow(w, err)
+}
+
+// AppendText implements encoding.TextAppender.
+func (i Int16) AppendText(b []byte) ([]byte, error) {
+	return i.Int64().AppendText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (i Int16) MarshalBinary() ([]byte, error) {
+	return i.Int64().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int16) UnmarshalBinary(data []byte) error {
+	var w Int64
+	err := w.UnmarshalBinary(data)
+	return i.narrow(w, err)
+}
+
+// GobEncode implements gob.GobEncoder.
+func (i Int16) GobEncode() ([]byte, error) {
+	return i.Int64().GobEncode()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (i *Int16) GobDecode(data []byte) error {
+	var w Int64
+	err := w.GobDecode(data)
+	return i.narrow(w, err)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i Int16) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.Int64().MarshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *Int16) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var w Int64
+	err := w.UnmarshalXML(d, start)
+	return i.narrow(w, err)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i Int16) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.Int64().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *Int16) UnmarshalXMLAttr(attr xml.Attr) error {
+	var w Int64
+	err := w.UnmarshalXMLAttr(attr)
+	return i.narrow(w, err)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Int16) MarshalYAML() (interface{}, error) {
+	return i.Int64().MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int16) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var w Int64
+	err := w.UnmarshalYAML(unmarshal)
+	return i.narrow(w, err)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i Int16) MarshalCBOR() ([]byte, error) {
+	return i.Int64().MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *Int16) UnmarshalCBOR(data []byte) error {
+	var w Int64
+	err := w.UnmarshalCBOR(data)
+	return i.narrow(w, err)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+func (i Int16) MarshalMsgpack() ([]byte, error) {
+	return i.Int64().MarshalMsgpack()
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+func (i *Int16) UnmarshalMsgpack(data []byte) error {
+	var w Int64
+	err := w.UnmarshalMsgpack(data)
+	return i.narrow(w, err)
+}
+
+// AppendText implements encoding.TextAppender.
+func (i Int8) AppendText(b []byte) ([]byte, error) {
+	return i.Int64().AppendText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (i Int8) MarshalBinary() ([]byte, error) {
+	return i.Int64().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (i *Int8) UnmarshalBinary(data []byte) error {
+	var w Int64
+	err := w.UnmarshalBinary(data)
+	return i.narrow(w, err)
+}
+
+// GobEncode implements gob.GobEncoder.
+func (i Int8) GobEncode() ([]byte, error) {
+	return i.Int64().GobEncode()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (i *Int8) GobDecode(data []byte) error {
+	var w Int64
+	err := w.GobDecode(data)
+	return i.narrow(w, err)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (i Int8) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.Int64().MarshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (i *Int8) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var w Int64
+	err := w.UnmarshalXML(d, start)
+	return i.narrow(w, err)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (i Int8) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.Int64().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (i *Int8) UnmarshalXMLAttr(attr xml.Attr) error {
+	var w Int64
+	err := w.UnmarshalXMLAttr(attr)
+	return i.narrow(w, err)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (i Int8) MarshalYAML() (interface{}, error) {
+	return i.Int64().MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (i *Int8) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var w Int64
+	err := w.UnmarshalYAML(unmarshal)
+	return i.narrow(w, err)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (i Int8) MarshalCBOR() ([]byte, error) {
+	return i.Int64().MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (i *Int8) UnmarshalCBOR(data []byte) error {
+	var w Int64
+	err := w.UnmarshalCBOR(data)
+	return i.narrow(w, err)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+func (i Int8) MarshalMsgpack() ([]byte, error) {
+	return i.Int64().MarshalMsgpack()
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+func (i *Int8) UnmarshalMsgpack(data []byte) error {
+	var w Int64
+	err := w.UnmarshalMsgpack(data)
+	return i.narrow(w, err)
+}
+
+// AppendText implements encoding.TextAppender.
+func (u Uint32) AppendText(b []byte) ([]byte, error) {
+	return u.Int64().AppendText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (u Uint32) MarshalBinary() ([]byte, error) {
+	return u.Int64().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint32) UnmarshalBinary(data []byte) error {
+	var w Int64
+	err := w.UnmarshalBinary(data)
+	return u.narrow(w, err)
+}
+
+// GobEncode implements gob.GobEncoder.
+func (u Uint32) GobEncode() ([]byte, error) {
+	return u.Int64().GobEncode()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (u *Uint32) GobDecode(data []byte) error {
+	var w Int64
+	err := w.GobDecode(data)
+	return u.narrow(w, err)
+}
+
+// MarshalXML implements xml.Marshaler.
+func (u Uint32) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return u.Int64().MarshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (u *Uint32) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var w Int64
+	err := w.UnmarshalXML(d, start)
+	return u.narrow(w, err)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (u Uint32) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return u.Int64().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *Uint32) UnmarshalXMLAttr(attr xml.Attr) error {
+	var w Int64
+	err := w.UnmarshalXMLAttr(attr)
+	return u.narrow(w, err)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u Uint32) MarshalYAML() (interface{}, error) {
+	return u.Int64().MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Uint32) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var w Int64
+	err := w.UnmarshalYAML(unmarshal)
+	return u.narrow(w, err)
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (u Uint32) MarshalCBOR() ([]byte, error) {
+	return u.Int64().MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+func (u *Uint32) UnmarshalCBOR(data []byte) error {
+	var w Int64
+	err := w.UnmarshalCBOR(data)
+	return u.narrow(w, err)
+}
+
+// MarshalMsgpack implements msgpack.Marshaler.
+func (u Uint32) MarshalMsgpack() ([]byte, error) {
+	return u.Int64().MarshalMsgpack()
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler.
+func (u *Uint32) UnmarshalMsgpack(data []byte) error {
+	var w Int64
+	err := w.UnmarshalMsgpack(data)
+	return u.narrow(w, err)
+}
@@ -0,0 +1,126 @@
+package nullint64
+
+import "fmt"
+
+// MarshalSliceJSON encodes vs as a JSON array. The output is identical to
+// json.Marshal(vs) but is built in a single buffer rather than calling
+// MarshalJSON for every element.
+func MarshalSliceJSON(vs []Int64) ([]byte, error) {
+	if vs == nil {
+		return append([]byte(nil), NullBytes...), nil
+	}
+
+	b := make([]byte, 0, 2+len(vs)*8)
+	b = append(b, '[')
+	for k, v := range vs {
+		if k > 0 {
+			b = append(b, ',')
+		}
+		b = v.AppendJSON(b)
+	}
+	b = append(b, ']')
+	return b, nil
+}
+
+// Int64Slice is a slice of Int64 values.
+type Int64Slice []Int64
+
+// ScanAppend scans a single element as Int64.Scan does and appends it to
+// the slice, so repeated driver callbacks can build up an array column. A
+// nil value appends a null. Nothing is appended if scanning fails.
+func (s *Int64Slice) ScanAppend(value interface{}) error {
+	var i Int64
+	if err := i.Scan(value); err != nil {
+		return err
+	}
+	*s = append(*s, i)
+	return nil
+}
+
+// SliceToColumnar splits vs into a values array and a validity array, the
+// layout used by columnar formats such as Apache Arrow. Null entries hold 0
+// in values.
+func SliceToColumnar(vs []Int64) (values []int64, validity []bool) {
+	values = make([]int64, len(vs))
+	validity = make([]bool, len(vs))
+	for k, v := range vs {
+		if v.Valid {
+			values[k] = v.Int64
+			validity[k] = true
+		}
+	}
+	return values, validity
+}
+
+// ColumnarToSlice is the inverse of SliceToColumnar. It returns an error if
+// values and validity differ in length.
+func ColumnarToSlice(values []int64, validity []bool) ([]Int64, error) {
+	if len(values) != len(validity) {
+		return nil, fmt.Errorf("nullint64: %d values but %d validity entries", len(values), len(validity))
+	}
+	vs := make([]Int64, len(values))
+	for k := range values {
+		if validity[k] {
+			vs[k] = Int64From(values[k])
+		} else {
+			vs[k] = NewInt64(0, false)
+		}
+	}
+	return vs, nil
+}
+
+// SliceFrom converts vs to a slice of valid Int64 values.
+func SliceFrom(vs []int64) []Int64 {
+	out := make([]Int64, len(vs))
+	for k, v := range vs {
+		out[k] = Int64From(v)
+	}
+	return out
+}
+
+// SlicePtr converts vs to a slice of pointers as Ptr does, with nil for
+// null entries.
+func SlicePtr(vs []Int64) []*int64 {
+	out := make([]*int64, len(vs))
+	for k, v := range vs {
+		out[k] = v.Ptr()
+	}
+	return out
+}
+
+// SliceFromPtr is the inverse of SlicePtr, converting vs as Int64FromPtr
+// does with nil entries becoming null.
+func SliceFromPtr(vs []*int64) []Int64 {
+	out := make([]Int64, len(vs))
+	for k, v := range vs {
+		out[k] = Int64FromPtr(v)
+	}
+	return out
+}
+
+// NullPolicy selects how ValueSlice treats null entries.
+type NullPolicy int
+
+const (
+	// SkipNulls omits null entries, so the result may be shorter than
+	// the input.
+	SkipNulls NullPolicy = iota
+	// ZeroNulls replaces null entries with 0, keeping positions aligned
+	// with the input.
+	ZeroNulls
+)
+
+// ValueSlice converts vs to a slice of plain int64 values, handling null
+// entries according to policy.
+func ValueSlice(vs []Int64, policy NullPolicy) []int64 {
+	out := make([]int64, 0, len(vs))
+	for _, v := range vs {
+		switch {
+		case v.Valid:
+			out = append(out, v.Int64)
+		case policy == ZeroNulls:
+			out = append(out, 0)
+		}
+	}
+	return out
+}
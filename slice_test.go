@@ -0,0 +1,155 @@
+package nullint64
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMarshalSliceJSONMatchesJSONMarshal(t *testing.T) {
+	mixed := []Int64{Int64From(1), NewInt64(0, false), Int64From(0), {}, Int64From(math.MinInt64), Int64From(math.MaxInt64)}
+	tests := []struct {
+		name string
+		vs   []Int64
+	}{
+		{"nil", nil},
+		{"empty", []Int64{}},
+		{"one", []Int64{Int64From(-7)}},
+		{"mixed", mixed},
+	}
+	options := []struct {
+		name string
+		set  func(t *testing.T)
+	}{
+		{"default", func(t *testing.T) {}},
+		{"null as zero", func(t *testing.T) { setBool(t, &MarshalNullAsZero, true) }},
+		{"null as empty string", func(t *testing.T) { setBool(t, &MarshalNullAsEmptyString, true) }},
+		{"value formatter", func(t *testing.T) {
+			old := ValueFormatter
+			ValueFormatter = func(n int64) string { return "<" + strconv.FormatInt(n, 16) + ">" }
+			t.Cleanup(func() { ValueFormatter = old })
+		}},
+	}
+	for _, opt := range options {
+		t.Run(opt.name, func(t *testing.T) {
+			opt.set(t)
+			for _, tt := range tests {
+				got, err := MarshalSliceJSON(tt.vs)
+				if err != nil {
+					t.Fatalf("%s: MarshalSliceJSON: %v", tt.name, err)
+				}
+				want, err := json.Marshal(tt.vs)
+				if err != nil {
+					t.Fatalf("%s: json.Marshal: %v", tt.name, err)
+				}
+				if string(got) != string(want) {
+					t.Errorf("%s: MarshalSliceJSON = %s, json.Marshal = %s", tt.name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func benchSlice() []Int64 {
+	vs := make([]Int64, 1000)
+	for k := range vs {
+		if k%10 == 0 {
+			vs[k] = NewInt64(0, false)
+		} else {
+			vs[k] = Int64From(int64(k) * 7919)
+		}
+	}
+	return vs
+}
+
+func BenchmarkMarshalSliceJSON(b *testing.B) {
+	vs := benchSlice()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if _, err := MarshalSliceJSON(vs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalSliceJSONStdlib(b *testing.B) {
+	vs := benchSlice()
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if _, err := json.Marshal(vs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestScanAppend(t *testing.T) {
+	var s Int64Slice
+	for _, v := range []interface{}{int64(1), nil, "3"} {
+		if err := s.ScanAppend(v); err != nil {
+			t.Fatalf("ScanAppend(%v): %v", v, err)
+		}
+	}
+	if err := s.ScanAppend("x"); err == nil {
+		t.Error("ScanAppend(\"x\") succeeded")
+	}
+	want := Int64Slice{Int64From(1), NewInt64(0, false), Int64From(3)}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("slice = %+v, want %+v", s, want)
+	}
+}
+
+func TestColumnar(t *testing.T) {
+	vs := []Int64{Int64From(1), NewInt64(0, false), Int64From(0), {Int64: 9, Set: true}}
+	values, validity := SliceToColumnar(vs)
+	if !reflect.DeepEqual(values, []int64{1, 0, 0, 0}) || !reflect.DeepEqual(validity, []bool{true, false, true, false}) {
+		t.Fatalf("SliceToColumnar = %v, %v", values, validity)
+	}
+	back, err := ColumnarToSlice(values, validity)
+	if err != nil {
+		t.Fatalf("ColumnarToSlice: %v", err)
+	}
+	want := []Int64{Int64From(1), NewInt64(0, false), Int64From(0), NewInt64(0, false)}
+	if !reflect.DeepEqual(back, want) {
+		t.Errorf("ColumnarToSlice = %+v, want %+v", back, want)
+	}
+	if _, err := ColumnarToSlice([]int64{1}, nil); err == nil {
+		t.Error("ColumnarToSlice with mismatched lengths succeeded")
+	}
+	if v, ok := SliceToColumnar(nil); len(v) != 0 || len(ok) != 0 {
+		t.Errorf("SliceToColumnar(nil) = %v, %v", v, ok)
+	}
+}
+
+func TestSliceConversions(t *testing.T) {
+	if got := SliceFrom([]int64{1, 0}); !reflect.DeepEqual(got, []Int64{Int64From(1), Int64From(0)}) {
+		t.Errorf("SliceFrom = %+v", got)
+	}
+	if got := SliceFrom(nil); len(got) != 0 {
+		t.Errorf("SliceFrom(nil) = %+v", got)
+	}
+
+	vs := []Int64{Int64From(1), NewInt64(0, false), Int64From(0), {}}
+	ptrs := SlicePtr(vs)
+	if len(ptrs) != 4 || *ptrs[0] != 1 || ptrs[1] != nil || *ptrs[2] != 0 || ptrs[3] != nil {
+		t.Errorf("SlicePtr = %v", ptrs)
+	}
+	*ptrs[0] = 5
+	if vs[0].Int64 != 1 {
+		t.Error("SlicePtr result aliases the input")
+	}
+
+	tests := []struct {
+		policy NullPolicy
+		want   []int64
+	}{
+		{SkipNulls, []int64{1, 0}},
+		{ZeroNulls, []int64{1, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		if got := ValueSlice(vs, tt.policy); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ValueSlice(%v) = %v, want %v", tt.policy, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build go1.21
+
+package nullint64
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer, so log/slog renders a valid value as
+// an int64 and a null as nil. An Int64 that was never set resolves to an
+// empty group, which handlers omit from the output.
+func (i Int64) LogValue() slog.Value {
+	switch {
+	case i.Valid:
+		return slog.Int64Value(i.Int64)
+	case i.Set:
+		return slog.AnyValue(nil)
+	default:
+		return slog.GroupValue()
+	}
+}
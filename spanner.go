@@ -0,0 +1,21 @@
+package nullint64
+
+// EncodeSpanner implements the spanner.Encoder interface of
+// cloud.google.com/go/spanner. It returns the value as an *int64, which
+// the client writes as an INT64, or as NULL for a nil pointer if this
+// Int64 is null or unset. It needs no import of the spanner package.
+func (i Int64) EncodeSpanner() (interface{}, error) {
+	return i.Ptr(), nil
+}
+
+// DecodeSpanner implements the spanner.Decoder interface of
+// cloud.google.com/go/spanner. The client passes INT64 columns as their
+// decimal string, which is parsed as Scan parses it; a NULL column, whether
+// passed as nil or as a typed nil pointer, decodes as an explicit null.
+func (i *Int64) DecodeSpanner(input interface{}) error {
+	if input == nil || isNilPointer(input) {
+		i.SetNull()
+		return nil
+	}
+	return i.Scan(input)
+}
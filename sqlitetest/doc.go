@@ -0,0 +1,4 @@
+// Package sqlitetest holds integration tests running nullint64 types
+// through database/sql against an in-memory SQLite database. It is a
+// separate module so the SQLite driver isn't a dependency of nullint64.
+package sqlitetest
@@ -0,0 +1,88 @@
+package sqlitetest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ccakes/nullint64"
+	_ "modernc.org/sqlite"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	// Each connection to :memory: is a separate database.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE t (id INTEGER PRIMARY KEY, n BIGINT)`); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	return db
+}
+
+func TestRoundTrip(t *testing.T) {
+	db := openDB(t)
+	tests := []struct {
+		name string
+		in   nullint64.Int64
+	}{
+		{"valid", nullint64.Int64From(42)},
+		{"valid negative", nullint64.Int64From(-1 << 62)},
+		{"valid zero", nullint64.Int64From(0)},
+		{"null", nullint64.NewInt64(0, false)},
+		{"unset", nullint64.Int64{}},
+	}
+	for k, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := db.Exec(`INSERT INTO t (id, n) VALUES (?, ?)`, k, tt.in); err != nil {
+				t.Fatalf("insert: %v", err)
+			}
+			var out nullint64.Int64
+			if err := db.QueryRow(`SELECT n FROM t WHERE id = ?`, k).Scan(&out); err != nil {
+				t.Fatalf("select: %v", err)
+			}
+			if !out.WeakEqual(tt.in) || !out.Set || out.Valid != tt.in.Valid {
+				t.Errorf("round trip of %+v = %+v", tt.in, out)
+			}
+		})
+	}
+}
+
+func TestRoundTripNullColumn(t *testing.T) {
+	db := openDB(t)
+	if _, err := db.Exec(`INSERT INTO t (id, n) VALUES (1, NULL)`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	var isNull bool
+	if err := db.QueryRow(`SELECT n IS NULL FROM t WHERE id = 1`).Scan(&isNull); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if !isNull {
+		t.Fatal("NULL column read back as non-NULL")
+	}
+
+	out := nullint64.Int64From(7)
+	if err := db.QueryRow(`SELECT n FROM t WHERE id = 1`).Scan(&out); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if out.Valid || !out.Set || out.Int64 != 0 {
+		t.Errorf("Scan of NULL = %+v, want explicit null", out)
+	}
+}
+
+func TestValueWritesNull(t *testing.T) {
+	db := openDB(t)
+	if _, err := db.Exec(`INSERT INTO t (id, n) VALUES (1, ?), (2, ?)`, nullint64.NewInt64(0, false), nullint64.Int64From(0)); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	var nulls int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM t WHERE n IS NULL`).Scan(&nulls); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if nulls != 1 {
+		t.Errorf("%d NULL rows, want 1: a valid zero must not be written as NULL", nulls)
+	}
+}
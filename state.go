@@ -0,0 +1,41 @@
+package nullint64
+
+// State is one of the three states an Int64 can be in.
+type State int
+
+const (
+	// StateUnset is an Int64 that was never given a value, such as the
+	// zero Int64{} or a JSON field that was absent.
+	StateUnset State = iota
+	// StateNull is an Int64 explicitly set to null.
+	StateNull
+	// StateValid is an Int64 holding a value.
+	StateValid
+)
+
+func (s State) String() string {
+	switch s {
+	case StateUnset:
+		return "unset"
+	case StateNull:
+		return "null"
+	case StateValid:
+		return "valid"
+	default:
+		return "invalid state"
+	}
+}
+
+// State returns the state of this Int64 for use in exhaustive switches. As
+// with IsValid, an Int64 that is not Set is StateUnset whatever its Valid
+// field says.
+func (i Int64) State() State {
+	switch {
+	case !i.Set:
+		return StateUnset
+	case !i.Valid:
+		return StateNull
+	default:
+		return StateValid
+	}
+}
@@ -0,0 +1,34 @@
+package nullint64
+
+import (
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	var decoded, absent struct{ N Int64 }
+	if err := decoded.N.UnmarshalJSON([]byte("0")); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		in   Int64
+		want State
+		str  string
+	}{
+		{"zero value", Int64{}, StateUnset, "unset"},
+		{"absent field", absent.N, StateUnset, "unset"},
+		{"valid without Set", Int64{Int64: 1, Valid: true}, StateUnset, "unset"},
+		{"null", NewInt64(0, false), StateNull, "null"},
+		{"decoded zero", decoded.N, StateValid, "valid"},
+		{"value", Int64From(3), StateValid, "valid"},
+	}
+	for _, tt := range tests {
+		got := tt.in.State()
+		if got != tt.want || got.String() != tt.str {
+			t.Errorf("%s: State() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := State(7).String(); got != "invalid state" {
+		t.Errorf("State(7).String() = %q", got)
+	}
+}
@@ -0,0 +1,164 @@
+package nullint64
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ccakes/nullint64/internal/convert"
+)
+
+// String is a nullable string with the same Valid/Set semantics as Int64.
+// Unlike Int64, an empty JSON string is a valid value; only null decodes
+// as null.
+type String struct {
+	String string
+	Valid  bool
+	Set    bool
+}
+
+// NewString creates a new String
+func NewString(s string, valid bool) String {
+	return String{
+		String: s,
+		Valid:  valid,
+		Set:    true,
+	}
+}
+
+// StringFrom creates a new String that will always be valid.
+func StringFrom(s string) String {
+	return NewString(s, true)
+}
+
+// StringFromPtr creates a new String that will be null if s is nil.
+func StringFromPtr(s *string) String {
+	if s == nil {
+		return NewString("", false)
+	}
+	return NewString(*s, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (s String) IsValid() bool {
+	return s.Set && s.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (s String) IsSet() bool {
+	return s.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *String) UnmarshalJSON(data []byte) error {
+	s.Set = true
+	if bytes.Equal(data, NullBytes) {
+		s.String, s.Valid = "", false
+		return nil
+	}
+	if jsonKind(data) != "string" {
+		if !json.Valid(data) {
+			return json.Unmarshal(data, new(interface{}))
+		}
+		s.String, s.Valid = "", false
+		return fmt.Errorf("nullint64: cannot unmarshal JSON %s into String", jsonKind(data))
+	}
+	err := json.Unmarshal(data, &s.String)
+	s.Valid = err == nil
+	if !s.Valid {
+		s.String = ""
+	}
+	return err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text and a
+// non-empty TextNullToken decode as null; TextNullWords are not applied,
+// as they are ordinary strings.
+func (s *String) UnmarshalText(text []byte) error {
+	s.Set = true
+	if len(text) == 0 || (TextNullToken != "" && string(text) == TextNullToken) {
+		s.String, s.Valid = "", false
+		return nil
+	}
+	s.String, s.Valid = string(text), true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. A null is always encoded as
+// JSON null, as an empty string would read back as a valid "".
+func (s String) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return append([]byte(nil), NullBytes...), nil
+	}
+	return json.Marshal(s.String)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s String) MarshalText() ([]byte, error) {
+	if !s.Valid {
+		return []byte(TextNullToken), nil
+	}
+	return []byte(s.String), nil
+}
+
+// AppendText implements encoding.TextAppender, appending the string to b,
+// or TextNullToken if this String is null.
+func (s String) AppendText(b []byte) ([]byte, error) {
+	if !s.Valid {
+		return append(b, TextNullToken...), nil
+	}
+	return append(b, s.String...), nil
+}
+
+// SetValid changes this String's value and also sets it to be non-null.
+func (s *String) SetValid(v string) {
+	s.String = v
+	s.Valid = true
+	s.Set = true
+}
+
+// SetNull sets this String to an explicit null.
+func (s *String) SetNull() {
+	s.String = ""
+	s.Valid = false
+	s.Set = true
+}
+
+// Ptr returns a pointer to this String's value, or a nil pointer if this String is null.
+func (s String) Ptr() *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// IsZero returns true for invalid String's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (s String) IsZero() bool {
+	return isZero(s.Valid, s.Set)
+}
+
+// Scan implements the Scanner interface.
+func (s *String) Scan(value interface{}) error {
+	if value == nil || isNilPointer(value) {
+		s.String, s.Valid, s.Set = "", false, true
+		return nil
+	}
+	s.Set = true
+	if err := convert.ConvertAssign(&s.String, value); err != nil {
+		s.String, s.Valid = "", false
+		return err
+	}
+	s.Valid = true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (s String) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	return s.String, nil
+}
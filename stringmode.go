@@ -0,0 +1,24 @@
+package nullint64
+
+import "encoding/json"
+
+// Int64String is an Int64 that marshals to JSON as a quoted string, like
+// protobuf's JSON mapping of int64, so JavaScript clients don't lose
+// precision above 2^53. The digits are those MarshalText writes, so
+// TextBase and ValueFormatter apply. Nulls are encoded as for Int64.
+// Decoding accepts quoted and bare numbers alike, as Int64 does.
+type Int64String struct {
+	Int64
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Int64String) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return appendNullJSON(nil), nil
+	}
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
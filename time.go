@@ -0,0 +1,161 @@
+package nullint64
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Time is a nullable time.Time with the same Valid/Set semantics as Int64.
+// It encodes as an RFC 3339 string, as time.Time does.
+type Time struct {
+	Time  time.Time
+	Valid bool
+	Set   bool
+}
+
+// NewTime creates a new Time
+func NewTime(t time.Time, valid bool) Time {
+	return Time{
+		Time:  t,
+		Valid: valid,
+		Set:   true,
+	}
+}
+
+// TimeFrom creates a new Time that will always be valid.
+func TimeFrom(t time.Time) Time {
+	return NewTime(t, true)
+}
+
+// TimeFromPtr creates a new Time that will be null if t is nil.
+func TimeFromPtr(t *time.Time) Time {
+	if t == nil {
+		return NewTime(time.Time{}, false)
+	}
+	return NewTime(*t, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (t Time) IsValid() bool {
+	return t.Set && t.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (t Time) IsSet() bool {
+	return t.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler. As for Int64, an empty JSON
+// string decodes as null.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if bytes.Equal(data, NullBytes) || string(data) == `""` {
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	}
+	err := t.Time.UnmarshalJSON(data)
+	t.Valid = err == nil
+	if !t.Valid {
+		t.Time = time.Time{}
+	}
+	return err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *Time) UnmarshalText(text []byte) error {
+	t.Set = true
+	if len(text) == 0 || isNullWord(text) {
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	}
+	err := t.Time.UnmarshalText(text)
+	t.Valid = err == nil
+	if !t.Valid {
+		t.Time = time.Time{}
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler. A null is always encoded as
+// JSON null; the MarshalNullAs options are for numeric types only.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return append([]byte(nil), NullBytes...), nil
+	}
+	return t.Time.MarshalJSON()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t Time) MarshalText() ([]byte, error) {
+	if !t.Valid {
+		return []byte(TextNullToken), nil
+	}
+	return t.Time.MarshalText()
+}
+
+// AppendText implements encoding.TextAppender, appending the MarshalText
+// form of the value to b.
+func (t Time) AppendText(b []byte) ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return b, err
+	}
+	return append(b, text...), nil
+}
+
+// SetValid changes this Time's value and also sets it to be non-null.
+func (t *Time) SetValid(v time.Time) {
+	t.Time = v
+	t.Valid = true
+	t.Set = true
+}
+
+// SetNull sets this Time to an explicit null.
+func (t *Time) SetNull() {
+	t.Time = time.Time{}
+	t.Valid = false
+	t.Set = true
+}
+
+// Ptr returns a pointer to this Time's value, or a nil pointer if this Time is null.
+func (t Time) Ptr() *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// IsZero returns true for invalid Time's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (t Time) IsZero() bool {
+	return isZero(t.Valid, t.Set)
+}
+
+// Scan implements the Scanner interface. Only time.Time driver values are
+// accepted; drivers returning timestamps as text need their parse-time
+// option enabled.
+func (t *Time) Scan(value interface{}) error {
+	if value == nil || isNilPointer(value) {
+		t.Time, t.Valid, t.Set = time.Time{}, false, true
+		return nil
+	}
+	t.Set = true
+	v, ok := value.(time.Time)
+	if !ok {
+		t.Time, t.Valid = time.Time{}, false
+		return fmt.Errorf("nullint64: cannot scan %T into Time", value)
+	}
+	t.Time, t.Valid = v, true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (t Time) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
@@ -0,0 +1,30 @@
+package nullint64
+
+// TrackedInt64 is an Int64 that can report whether it has changed since a
+// snapshot, for ORMs building partial UPDATEs. The snapshot is a second
+// copy of the state, so a TrackedInt64 takes twice the memory of an Int64;
+// Int64 itself carries no tracking state so it stays small for the common
+// case. The embedded Int64's methods, such as SetValid and Scan, modify the
+// current state as usual.
+type TrackedInt64 struct {
+	Int64
+	snapshot Int64
+	tracked  bool
+}
+
+// Snapshot records the current state as the baseline for Dirty. Call it
+// after loading the value.
+func (t *TrackedInt64) Snapshot() {
+	t.snapshot = t.Int64
+	t.tracked = true
+}
+
+// Dirty reports whether the value has changed since the last Snapshot.
+// Changes to Set alone count, since explicitly setting a value is itself a
+// change for a partial update. Without a snapshot any set value is dirty.
+func (t TrackedInt64) Dirty() bool {
+	if !t.tracked {
+		return t.Set
+	}
+	return t.Int64 != t.snapshot
+}
@@ -0,0 +1,45 @@
+package nullint64
+
+import (
+	"testing"
+)
+
+func TestTrackedInt64Dirty(t *testing.T) {
+	var tr TrackedInt64
+	if tr.Dirty() {
+		t.Error("unset value without snapshot is dirty")
+	}
+	tr.SetValid(1)
+	if !tr.Dirty() {
+		t.Error("set value without snapshot is not dirty")
+	}
+
+	if err := tr.Scan(int64(5)); err != nil {
+		t.Fatal(err)
+	}
+	tr.Snapshot()
+	if tr.Dirty() {
+		t.Error("dirty right after Snapshot")
+	}
+	tr.SetValid(5)
+	if tr.Dirty() {
+		t.Error("setting the same value made it dirty")
+	}
+	tr.SetNull()
+	if !tr.Dirty() {
+		t.Error("SetNull after Snapshot is not dirty")
+	}
+	tr.SetValid(5)
+	if tr.Dirty() {
+		t.Error("restoring the snapshot value is still dirty")
+	}
+}
+
+func TestTrackedInt64SetOnly(t *testing.T) {
+	var tr TrackedInt64
+	tr.Snapshot()
+	tr.Set = true
+	if !tr.Dirty() {
+		t.Error("change of Set alone is not dirty")
+	}
+}
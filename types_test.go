@@ -0,0 +1,138 @@
+package nullint64
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// setBool sets the option *p to v for the duration of the test.
+func setBool(t *testing.T, p *bool, v bool) {
+	old := *p
+	*p = v
+	t.Cleanup(func() { *p = old })
+}
+
+func TestSiblingMarshalNullJSON(t *testing.T) {
+	numeric := []json.Marshaler{Float64{}, Uint64{}, Int32{}}
+	other := []json.Marshaler{Bool{}, String{}, Time{}}
+	tests := []struct {
+		name      string
+		asZero    bool
+		asEmpty   bool
+		wantBytes string
+	}{
+		{"default", false, false, "null"},
+		{"as zero", true, false, "0"},
+		{"as empty string", false, true, `""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setBool(t, &MarshalNullAsZero, tt.asZero)
+			setBool(t, &MarshalNullAsEmptyString, tt.asEmpty)
+			for _, n := range numeric {
+				got, err := n.MarshalJSON()
+				if err != nil || string(got) != tt.wantBytes {
+					t.Errorf("%T.MarshalJSON() = %s, %v, want %s", n, got, err, tt.wantBytes)
+				}
+			}
+			for _, n := range other {
+				got, err := n.MarshalJSON()
+				if err != nil || string(got) != "null" {
+					t.Errorf("%T.MarshalJSON() = %s, %v, want null", n, got, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSiblingScanNilPointer(t *testing.T) {
+	var f Float64
+	if err := f.Scan((*float64)(nil)); err != nil || f.Valid || !f.Set {
+		t.Errorf("Float64.Scan(nil pointer) = %+v, %v", f, err)
+	}
+	var b Bool
+	if err := b.Scan((*bool)(nil)); err != nil || b.Valid || !b.Set {
+		t.Errorf("Bool.Scan(nil pointer) = %+v, %v", b, err)
+	}
+	var s String
+	if err := s.Scan((*string)(nil)); err != nil || s.Valid || !s.Set {
+		t.Errorf("String.Scan(nil pointer) = %+v, %v", s, err)
+	}
+	var tm Time
+	if err := tm.Scan((*time.Time)(nil)); err != nil || tm.Valid || !tm.Set {
+		t.Errorf("Time.Scan(nil pointer) = %+v, %v", tm, err)
+	}
+	for _, v := range []interface{}{(*big.Int)(nil), (*string)(nil)} {
+		bi := BigIntFrom(big.NewInt(1))
+		if err := bi.Scan(v); err != nil || bi.Valid || bi.BigInt != nil || !bi.Set {
+			t.Errorf("BigInt.Scan(%T nil) = %+v, %v", v, bi, err)
+		}
+	}
+}
+
+func TestSiblingUnmarshalEmptyString(t *testing.T) {
+	data := []byte(`""`)
+	var f Float64
+	if err := f.UnmarshalJSON(data); err != nil || f.Valid || !f.Set {
+		t.Errorf("Float64.UnmarshalJSON(%s) = %+v, %v", data, f, err)
+	}
+	var b Bool
+	if err := b.UnmarshalJSON(data); err != nil || b.Valid || !b.Set {
+		t.Errorf("Bool.UnmarshalJSON(%s) = %+v, %v", data, b, err)
+	}
+	var tm Time
+	if err := tm.UnmarshalJSON(data); err != nil || tm.Valid || !tm.Set {
+		t.Errorf("Time.UnmarshalJSON(%s) = %+v, %v", data, tm, err)
+	}
+	var s String
+	if err := s.UnmarshalJSON(data); err != nil || !s.Valid || s.String != "" {
+		t.Errorf("String.UnmarshalJSON(%s) = %+v, %v, want valid empty", data, s, err)
+	}
+}
+
+func TestSiblingAppendText(t *testing.T) {
+	for _, v := range siblingValues {
+		want, err := v.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			t.Fatalf("%T.MarshalText(%+v): %v", v, v, err)
+		}
+		got, err := v.(textAppender).AppendText([]byte("x:"))
+		if err != nil || string(got) != "x:"+string(want) {
+			t.Errorf("%T.AppendText(%+v) = %q, %v, want x:%s", v, v, got, err, want)
+		}
+	}
+}
+
+func TestSiblingTextNullToken(t *testing.T) {
+	old := TextNullToken
+	TextNullToken = `\N`
+	t.Cleanup(func() { TextNullToken = old })
+
+	nulls := []interface{}{
+		NewUint64(0, false), NewFloat64(0, false), NewBool(false, false), NewString("", false),
+		NewTime(time.Time{}, false), NewBigInt(nil, false), NewInt32(0, false), NewInt8(0, false),
+	}
+	for _, v := range nulls {
+		text, err := v.(encoding.TextMarshaler).MarshalText()
+		if err != nil || string(text) != `\N` {
+			t.Errorf("%T.MarshalText(null) = %q, %v, want \\N", v, text, err)
+		}
+		if a, ok := v.(textAppender); ok {
+			if got, err := a.AppendText([]byte("x:")); err != nil || string(got) != `x:\N` {
+				t.Errorf("%T.AppendText(null) = %q, %v, want x:\\N", v, got, err)
+			}
+		}
+		p := newOf(v)
+		if err := p.(encoding.TextUnmarshaler).UnmarshalText(text); err != nil || !sameValue(elem(p), reflectNull(p)) {
+			t.Errorf("%T.UnmarshalText(%q) = %+v, %v, want null", v, text, elem(p), err)
+		}
+	}
+
+	var s String
+	if err := s.UnmarshalText([]byte(`\n`)); err != nil || s != StringFrom(`\n`) {
+		t.Errorf("String.UnmarshalText(%q) = %+v, %v, want valid", `\n`, s, err)
+	}
+}
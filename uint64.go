@@ -0,0 +1,195 @@
+package nullint64
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ccakes/nullint64/internal/convert"
+)
+
+// Uint64 is a nullable uint64 with the same Valid/Set semantics as Int64.
+// Values above math.MaxInt64 can't be passed to or read from most drivers,
+// since database/sql has no unsigned driver type; Value rejects them.
+type Uint64 struct {
+	Uint64 uint64
+	Valid  bool
+	Set    bool
+}
+
+// NewUint64 creates a new Uint64.
+func NewUint64(v uint64, valid bool) Uint64 {
+	return Uint64{Uint64: v, Valid: valid, Set: true}
+}
+
+// Uint64From creates a new Uint64 that will always be valid.
+func Uint64From(v uint64) Uint64 {
+	return NewUint64(v, true)
+}
+
+// Uint64FromPtr creates a new Uint64 that will be null if v is nil.
+func Uint64FromPtr(v *uint64) Uint64 {
+	if v == nil {
+		return NewUint64(0, false)
+	}
+	return NewUint64(*v, true)
+}
+
+// IsValid returns true if this carries an explicit value and
+// is not null.
+func (u Uint64) IsValid() bool {
+	return u.Set && u.Valid
+}
+
+// IsSet returns true if this carries an explicit value (null inclusive)
+func (u Uint64) IsSet() bool {
+	return u.Set
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts numbers, null and
+// quoted numbers in TextBase as Int64 does, including honoring
+// LegacyZeroIsNull.
+func (u *Uint64) UnmarshalJSON(data []byte) error {
+	u.Set = true
+	if bytes.Equal(data, NullBytes) {
+		u.Uint64, u.Valid = 0, false
+		return nil
+	}
+
+	var err error
+	switch jsonKind(data) {
+	case "number":
+		err = json.Unmarshal(data, &u.Uint64)
+	case "string":
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		if len(str) == 0 {
+			u.Uint64, u.Valid = 0, false
+			if RejectEmptyString {
+				return errors.New("nullint64: empty string is not a valid Uint64")
+			}
+			return nil
+		}
+		u.Uint64, err = strconv.ParseUint(str, TextBase, 64)
+	default:
+		if !json.Valid(data) {
+			return json.Unmarshal(data, new(interface{}))
+		}
+		err = fmt.Errorf("nullint64: cannot unmarshal JSON %s into Uint64", jsonKind(data))
+	}
+
+	u.Valid = jsonValid(err, u.Uint64 == 0)
+	if !u.Valid {
+		u.Uint64 = 0
+	}
+	return err
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *Uint64) UnmarshalText(text []byte) error {
+	u.Set = true
+	if len(text) == 0 || isNullWord(text) {
+		u.Uint64, u.Valid = 0, false
+		return nil
+	}
+	var err error
+	u.Uint64, err = strconv.ParseUint(string(text), TextBase, 64)
+	u.Valid = err == nil
+	if !u.Valid {
+		u.Uint64 = 0
+	}
+	return err
+}
+
+// MarshalJSON implements json.Marshaler. Nulls are encoded as for Int64.
+func (u Uint64) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return appendNullJSON(nil), nil
+	}
+	return strconv.AppendUint(nil, u.Uint64, 10), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u Uint64) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte(TextNullToken), nil
+	}
+	return strconv.AppendUint(nil, u.Uint64, TextBase), nil
+}
+
+// AppendText implements encoding.TextAppender, appending the MarshalText
+// form of the value to b.
+func (u Uint64) AppendText(b []byte) ([]byte, error) {
+	if !u.Valid {
+		return append(b, TextNullToken...), nil
+	}
+	return strconv.AppendUint(b, u.Uint64, TextBase), nil
+}
+
+// SetValid changes this Uint64's value and also sets it to be non-null.
+func (u *Uint64) SetValid(v uint64) {
+	u.Uint64, u.Valid, u.Set = v, true, true
+}
+
+// SetNull sets this Uint64 to an explicit null.
+func (u *Uint64) SetNull() {
+	u.Uint64, u.Valid, u.Set = 0, false, true
+}
+
+// Ptr returns a pointer to this Uint64's value, or a nil pointer if this Uint64 is null.
+func (u Uint64) Ptr() *uint64 {
+	if !u.Valid {
+		return nil
+	}
+	return &u.Uint64
+}
+
+// IsZero returns true for invalid Uint64's, for omitempty support, or
+// only for unset ones if OmitUnsetOnly is set.
+func (u Uint64) IsZero() bool {
+	return isZero(u.Valid, u.Set)
+}
+
+// Scan implements the Scanner interface. Negative values are an error.
+func (u *Uint64) Scan(value interface{}) error {
+	if value == nil || isNilPointer(value) {
+		u.Uint64, u.Valid, u.Set = 0, false, true
+		return nil
+	}
+	u.Set = true
+	var err error
+	switch v := value.(type) {
+	case uint64:
+		u.Uint64 = v
+	case int64:
+		if v < 0 {
+			err = fmt.Errorf("converting driver.Value type %T (%d) to a uint64: value out of range", v, v)
+		}
+		u.Uint64 = uint64(v)
+	default:
+		err = convert.ConvertAssign(&u.Uint64, value)
+	}
+	if err != nil {
+		u.Uint64, u.Valid = 0, false
+		return newScanError(value, err)
+	}
+	u.Valid = true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (u Uint64) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	if u.Uint64 > math.MaxInt64 {
+		return nil, fmt.Errorf("nullint64: %d exceeds the int64 range of driver values", u.Uint64)
+	}
+	return int64(u.Uint64), nil
+}
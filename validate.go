@@ -0,0 +1,78 @@
+package nullint64
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// A Rule checks an Int64 for Validate.
+type Rule func(Int64) error
+
+// Validate checks this Int64 against each rule in turn and returns the
+// first error. With no rules it always succeeds.
+func (i Int64) Validate(rules ...Rule) error {
+	for _, r := range rules {
+		if err := r(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Required returns a Rule rejecting an Int64 that was never set. An
+// explicit null passes; combine it with NonNull to require a value.
+func Required() Rule {
+	return func(i Int64) error {
+		if !i.Set {
+			return errors.New("nullint64: value is required")
+		}
+		return nil
+	}
+}
+
+// NonNull returns a Rule rejecting an explicit null. An unset Int64 passes,
+// so optional fields may be omitted but not nulled.
+func NonNull() Rule {
+	return func(i Int64) error {
+		if i.Set && !i.Valid {
+			return errors.New("nullint64: value must not be null")
+		}
+		return nil
+	}
+}
+
+// Min returns a Rule rejecting valid values below n. Nulls pass.
+func Min(n int64) Rule {
+	return func(i Int64) error {
+		if i.Valid && i.Int64 < n {
+			return fmt.Errorf("nullint64: %d is less than the minimum %d", i.Int64, n)
+		}
+		return nil
+	}
+}
+
+// Max returns a Rule rejecting valid values above n. Nulls pass.
+func Max(n int64) Rule {
+	return func(i Int64) error {
+		if i.Valid && i.Int64 > n {
+			return fmt.Errorf("nullint64: %d is greater than the maximum %d", i.Int64, n)
+		}
+		return nil
+	}
+}
+
+// ValidatorValue is a custom type function for go-playground/validator, so
+// tags such as validate:"omitempty,min=1" apply to the value of an Int64:
+//
+//	v.RegisterCustomTypeFunc(nullint64.ValidatorValue, nullint64.Int64{})
+//
+// It returns the int64 of a valid Int64 and nil for a null or unset one,
+// which validator treats as empty. Note that validator's omitempty also
+// treats a valid 0 as empty and skips its other rules.
+func ValidatorValue(field reflect.Value) interface{} {
+	if i, ok := field.Interface().(Int64); ok && i.Valid {
+		return i.Int64
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package nullint64
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// xsiNamespace is the XML Schema instance namespace defining xsi:nil.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// textNullable is a pointer to one of the nullable types, all of which
+// encode in XML through their text form.
+type textNullable interface {
+	MarshalText() ([]byte, error)
+	UnmarshalText(text []byte) error
+	SetNull()
+}
+
+// marshalXML encodes v, with the given state, as Int64.MarshalXML does.
+func marshalXML(e *xml.Encoder, start xml.StartElement, v textNullable, set, valid bool) error {
+	if !set {
+		return nil
+	}
+	if !valid {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+	text, err := v.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+// unmarshalXML decodes an element into v as Int64.UnmarshalXML does,
+// trimming the character data first if trim is set.
+func unmarshalXML(d *xml.Decoder, start xml.StartElement, v textNullable, trim bool) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	for _, a := range start.Attr {
+		if a.Name.Local == "nil" && (a.Name.Space == xsiNamespace || a.Name.Space == "xsi") && a.Value == "true" {
+			v.SetNull()
+			return nil
+		}
+	}
+	if trim {
+		s = strings.TrimSpace(s)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// marshalXMLAttr encodes v as Int64.MarshalXMLAttr does.
+func marshalXMLAttr(name xml.Name, v textNullable, set bool) (xml.Attr, error) {
+	if !set {
+		return xml.Attr{}, nil
+	}
+	text, err := v.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// MarshalXML implements xml.Marshaler. A valid value is encoded as the
+// element's character data, a null as an empty element carrying
+// xsi:nil="true", and an Int64 that is not Set is omitted entirely.
+func (i Int64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, &i, i.Set, i.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler. An element with xsi:nil="true"
+// or with no character data decodes as null; otherwise the trimmed
+// character data is parsed as UnmarshalText parses it.
+func (i *Int64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, i, true)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr. The attribute is omitted if
+// this Int64 is not Set, and holds TextNullToken if it is null.
+func (i Int64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, &i, i.Set)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, parsing the value as
+// UnmarshalText does, so an empty value decodes as null.
+func (i *Int64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.UnmarshalText([]byte(strings.TrimSpace(attr.Value)))
+}
+
+// MarshalXML implements xml.Marshaler as Int64.MarshalXML does.
+func (u Uint64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, &u, u.Set, u.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler as Int64.UnmarshalXML does.
+func (u *Uint64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, u, true)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr as Int64.MarshalXMLAttr does.
+func (u Uint64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, &u, u.Set)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (u *Uint64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return u.UnmarshalText([]byte(strings.TrimSpace(attr.Value)))
+}
+
+// MarshalXML implements xml.Marshaler as Int64.MarshalXML does.
+func (f Float64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, &f, f.Set, f.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler as Int64.UnmarshalXML does.
+func (f *Float64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, f, true)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr as Int64.MarshalXMLAttr does.
+func (f Float64) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, &f, f.Set)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (f *Float64) UnmarshalXMLAttr(attr xml.Attr) error {
+	return f.UnmarshalText([]byte(strings.TrimSpace(attr.Value)))
+}
+
+// MarshalXML implements xml.Marshaler as Int64.MarshalXML does.
+func (b Bool) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, &b, b.Set, b.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler as Int64.UnmarshalXML does.
+func (b *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, b, true)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr as Int64.MarshalXMLAttr does.
+func (b Bool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, &b, b.Set)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (b *Bool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.UnmarshalText([]byte(strings.TrimSpace(attr.Value)))
+}
+
+// MarshalXML implements xml.Marshaler as Int64.MarshalXML does.
+func (s String) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, &s, s.Set, s.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Unlike the other types the
+// character data is kept as it is, surrounding whitespace included, and
+// only an empty element or xsi:nil="true" decodes as null.
+func (s *String) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, s, false)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr as Int64.MarshalXMLAttr does.
+func (s String) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, &s, s.Set)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, keeping the value as it
+// is; an empty value decodes as null.
+func (s *String) UnmarshalXMLAttr(attr xml.Attr) error {
+	return s.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML implements xml.Marshaler as Int64.MarshalXML does, with a
+// valid value in RFC 3339 form.
+func (t Time) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXML(e, start, &t, t.Set, t.Valid)
+}
+
+// UnmarshalXML implements xml.Unmarshaler as Int64.UnmarshalXML does.
+func (t *Time) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalXML(d, start, t, true)
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr as Int64.MarshalXMLAttr does.
+func (t Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalXMLAttr(name, &t, t.Set)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (t *Time) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.UnmarshalText([]byte(strings.TrimSpace(attr.Value)))
+}
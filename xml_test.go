@@ -0,0 +1,90 @@
+package nullint64
+
+import (
+	"encoding/xml"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type xmlRecord struct {
+	XMLName xml.Name `xml:"r"`
+	I       Int64    `xml:"i"`
+	U       Uint64   `xml:"u"`
+	F       Float64  `xml:"f"`
+	B       Bool     `xml:"b"`
+	S       String   `xml:"s"`
+	T       Time     `xml:"t"`
+	N       Int16    `xml:"n"`
+	A       Uint32   `xml:"a,attr"`
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	tests := []xmlRecord{
+		{
+			I: Int64From(-1), U: Uint64From(math.MaxUint64), F: Float64From(0.25), B: BoolFrom(true),
+			S: StringFrom(" padded "), T: TimeFrom(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+			N: Int16From(-300), A: Uint32From(7),
+		},
+		{
+			I: NewInt64(0, false), U: NewUint64(0, false), F: NewFloat64(0, false), B: NewBool(false, false),
+			S: NewString("", false), T: NewTime(time.Time{}, false), N: NewInt16(0, false),
+		},
+		{},
+	}
+	for _, in := range tests {
+		b, err := xml.Marshal(in)
+		if err != nil {
+			t.Fatalf("Marshal(%+v): %v", in, err)
+		}
+		var out xmlRecord
+		if err := xml.Unmarshal(b, &out); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", b, err)
+		}
+		out.XMLName = xml.Name{}
+		if !out.T.Time.Equal(in.T.Time) {
+			t.Errorf("round trip of T via %s = %+v, want %+v", b, out.T, in.T)
+		}
+		out.T.Time = in.T.Time
+		if out != in {
+			t.Errorf("round trip via %s = %+v, want %+v", b, out, in)
+		}
+	}
+}
+
+func TestMarshalXMLSiblings(t *testing.T) {
+	b, err := xml.Marshal(xmlRecord{S: NewString("", false), B: BoolFrom(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `<r><b>false</b><s xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></s></r>`
+	if string(b) != want {
+		t.Errorf("Marshal = %s, want %s", b, want)
+	}
+}
+
+func TestUnmarshalXMLSiblings(t *testing.T) {
+	var out xmlRecord
+	data := `<r a=" 9 "><u> 18 </u><b>
+		true
+	</b><s>  kept  </s><f></f><t xsi:nil="true">2020-01-01T00:00:00Z</t></r>`
+	if err := xml.Unmarshal([]byte(data), &out); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.U != Uint64From(18) || out.B != BoolFrom(true) || out.A != Uint32From(9) {
+		t.Errorf("trimmed values = %+v, %+v, %+v", out.U, out.B, out.A)
+	}
+	if out.S != StringFrom("  kept  ") {
+		t.Errorf("String = %+v, want whitespace kept", out.S)
+	}
+	if out.F != NewFloat64(0, false) || out.T != NewTime(time.Time{}, false) {
+		t.Errorf("empty and xsi:nil = %+v, %+v, want null", out.F, out.T)
+	}
+
+	for _, data := range []string{`<r><n>40000</n></r>`, `<r a="-1"></r>`, `<r><b>maybe</b></r>`} {
+		if err := xml.NewDecoder(strings.NewReader(data)).Decode(new(xmlRecord)); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", data)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package nullint64
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MarshalYAML implements the yaml.Marshaler interfaces of gopkg.in/yaml.v2
+// and yaml.v3, encoding null as a YAML null and a valid value as an
+// integer. It needs no import of either package.
+func (i Int64) MarshalYAML() (interface{}, error) {
+	if !i.Valid {
+		return nil, nil
+	}
+	return i.Int64, nil
+}
+
+// UnmarshalYAML implements the yaml.v2 Unmarshaler interface, which yaml.v3
+// also honors. It accepts integers, floats with no fractional part, and
+// strings parsed as UnmarshalText parses them; an empty string decodes as
+// null.
+//
+// Both yaml packages skip unmarshalers for null nodes, so an explicit
+// null leaves the field untouched, just as an absent key does: Set can
+// distinguish a value from a missing key, but not null from missing. Use
+// an empty string where an explicit null must be recorded.
+func (i *Int64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	i.Set = true
+	var err error
+	switch x := v.(type) {
+	case nil:
+		i.Int64, i.Valid = 0, false
+		return nil
+	case string:
+		return i.UnmarshalText([]byte(x))
+	case int:
+		i.Int64 = int64(x)
+	case int64:
+		i.Int64 = x
+	case uint64:
+		if x > math.MaxInt64 {
+			err = fmt.Errorf("nullint64: YAML integer %d overflows Int64", x)
+		}
+		i.Int64 = int64(x)
+	case float64:
+		if x != math.Trunc(x) || x < math.MinInt64 || x >= -math.MinInt64 {
+			err = fmt.Errorf("nullint64: cannot unmarshal YAML number %v into Int64", x)
+		}
+		i.Int64 = int64(x)
+	default:
+		err = fmt.Errorf("nullint64: cannot unmarshal YAML %T into Int64", v)
+	}
+	i.Valid = err == nil
+	if !i.Valid {
+		i.Int64 = 0
+	}
+	return err
+}
+
+// MarshalYAML implements the yaml.Marshaler interfaces, encoding null as a
+// YAML null and a valid value as an integer.
+func (u Uint64) MarshalYAML() (interface{}, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.Uint64, nil
+}
+
+// UnmarshalYAML implements the yaml.v2 Unmarshaler interface. It accepts
+// non-negative integers, floats with no fractional part, and strings
+// parsed as UnmarshalText parses them. As for Int64, an explicit null
+// leaves the field untouched.
+func (u *Uint64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	u.Set = true
+	var err error
+	switch x := v.(type) {
+	case nil:
+		u.Uint64, u.Valid = 0, false
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(x))
+	case int:
+		if x < 0 {
+			err = fmt.Errorf("nullint64: YAML integer %d overflows Uint64", x)
+		}
+		u.Uint64 = uint64(x)
+	case int64:
+		if x < 0 {
+			err = fmt.Errorf("nullint64: YAML integer %d overflows Uint64", x)
+		}
+		u.Uint64 = uint64(x)
+	case uint64:
+		u.Uint64 = x
+	case float64:
+		if x != math.Trunc(x) || x < 0 || x >= math.MaxUint64 {
+			err = fmt.Errorf("nullint64: cannot unmarshal YAML number %v into Uint64", x)
+		}
+		u.Uint64 = uint64(x)
+	default:
+		err = fmt.Errorf("nullint64: cannot unmarshal YAML %T into Uint64", v)
+	}
+	u.Valid = err == nil
+	if !u.Valid {
+		u.Uint64 = 0
+	}
+	return err
+}
+
+// MarshalYAML implements the yaml.Marshaler interfaces, encoding null as a
+// YAML null and a valid value as a float.
+func (f Float64) MarshalYAML() (interface{}, error) {
+	if !f.Valid {
+		return nil, nil
+	}
+	return f.Float64, nil
+}
+
+// UnmarshalYAML implements the yaml.v2 Unmarshaler interface. It accepts
+// any YAML number, and strings parsed as UnmarshalText parses them.
+func (f *Float64) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	f.Set = true
+	var err error
+	switch x := v.(type) {
+	case nil:
+		f.Float64, f.Valid = 0, false
+		return nil
+	case string:
+		return f.UnmarshalText([]byte(x))
+	case int:
+		f.Float64 = float64(x)
+	case int64:
+		f.Float64 = float64(x)
+	case uint64:
+		f.Float64 = float64(x)
+	case float64:
+		f.Float64 = x
+	default:
+		err = fmt.Errorf("nullint64: cannot unmarshal YAML %T into Float64", v)
+	}
+	f.Valid = err == nil
+	if !f.Valid {
+		f.Float64 = 0
+	}
+	return err
+}
+
+// MarshalYAML implements the yaml.Marshaler interfaces, encoding null as a
+// YAML null and a valid value as a bool.
+func (b Bool) MarshalYAML() (interface{}, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.Bool, nil
+}
+
+// UnmarshalYAML implements the yaml.v2 Unmarshaler interface. It accepts
+// YAML booleans, and strings parsed as UnmarshalText parses them.
+func (b *Bool) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	switch x := v.(type) {
+	case nil:
+		b.SetNull()
+	case bool:
+		b.SetValid(x)
+	case string:
+		return b.UnmarshalText([]byte(x))
+	default:
+		b.SetNull()
+		return fmt.Errorf("nullint64: cannot unmarshal YAML %T into Bool", v)
+	}
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interfaces, encoding null as a
+// YAML null and a valid value as a string.
+func (s String) MarshalYAML() (interface{}, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	return s.String, nil
+}
+
+// UnmarshalYAML implements the yaml.v2 Unmarshaler interface. Any scalar
+// decodes as its string form, and unlike the other types an empty string
+// is a valid value.
+func (s *String) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	s.SetValid(v)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interfaces, encoding null as a
+// YAML null and a valid value as a timestamp.
+func (t Time) MarshalYAML() (interface{}, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+// UnmarshalYAML implements the yaml.v2 Unmarshaler interface. It accepts
+// YAML timestamps, and strings parsed as UnmarshalText parses them.
+func (t *Time) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	switch x := v.(type) {
+	case nil:
+		t.SetNull()
+	case time.Time:
+		t.SetValid(x)
+	case string:
+		return t.UnmarshalText([]byte(x))
+	default:
+		t.SetNull()
+		return fmt.Errorf("nullint64: cannot unmarshal YAML %T into Time", v)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package nullint64
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type yamlDecoder interface {
+	UnmarshalYAML(unmarshal func(interface{}) error) error
+}
+
+// yamlNode returns an unmarshal function that stores v, as a YAML decoder
+// would have decoded it, into the target it is called with.
+func yamlNode(v interface{}) func(interface{}) error {
+	return func(target interface{}) error {
+		rv := reflect.ValueOf(target).Elem()
+		if v == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if rv.Kind() == reflect.String {
+			// The yaml packages decode any scalar into a string as its text.
+			rv.SetString(fmt.Sprint(v))
+			return nil
+		}
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	ts := time.Date(2001, 12, 14, 21, 59, 43, 0, time.UTC)
+	tests := []struct {
+		in   interface{ MarshalYAML() (interface{}, error) }
+		want interface{}
+	}{
+		{Int64From(-3), int64(-3)},
+		{Uint64From(math.MaxUint64), uint64(math.MaxUint64)},
+		{Float64From(2.5), 2.5},
+		{BoolFrom(false), false},
+		{StringFrom(""), ""},
+		{TimeFrom(ts), ts},
+		{Int8From(-8), int64(-8)},
+		{NewUint64(0, false), nil},
+		{NewString("x", false), nil},
+		{Time{}, nil},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.MarshalYAML()
+		if err != nil || got != tt.want {
+			t.Errorf("%T.MarshalYAML(%+v) = %#v, %v, want %#v", tt.in, tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	ts := time.Date(2001, 12, 14, 21, 59, 43, 0, time.UTC)
+	tests := []struct {
+		p    yamlDecoder
+		node interface{}
+		want interface{}
+	}{
+		{new(Uint64), 5, Uint64From(5)},
+		{new(Uint64), uint64(math.MaxUint64), Uint64From(math.MaxUint64)},
+		{new(Uint64), 3.0, Uint64From(3)},
+		{new(Uint64), "42", Uint64From(42)},
+		{new(Uint64), "", NewUint64(0, false)},
+		{new(Float64), 2, Float64From(2)},
+		{new(Float64), 0.5, Float64From(0.5)},
+		{new(Float64), "1e3", Float64From(1000)},
+		{new(Bool), true, BoolFrom(true)},
+		{new(Bool), "false", BoolFrom(false)},
+		{new(Bool), "", NewBool(false, false)},
+		{new(String), "", StringFrom("")},
+		{new(String), "abc", StringFrom("abc")},
+		{new(String), 12, StringFrom("12")},
+		{new(Time), ts, TimeFrom(ts)},
+		{new(Time), "2001-12-14T21:59:43Z", TimeFrom(ts)},
+		{new(Time), "", NewTime(time.Time{}, false)},
+		{new(Int16), 300, Int16From(300)},
+		{new(Uint32), "7", Uint32From(7)},
+	}
+	for _, tt := range tests {
+		if err := tt.p.UnmarshalYAML(yamlNode(tt.node)); err != nil || !sameValue(elem(tt.p), tt.want) {
+			t.Errorf("%T.UnmarshalYAML(%#v) = %+v, %v, want %+v", tt.p, tt.node, elem(tt.p), err, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalYAMLErrors(t *testing.T) {
+	tests := []struct {
+		p    yamlDecoder
+		node interface{}
+	}{
+		{new(Uint64), -1},
+		{new(Uint64), int64(-1)},
+		{new(Uint64), 1.5},
+		{new(Uint64), true},
+		{new(Float64), true},
+		{new(Float64), "x"},
+		{new(Bool), 1},
+		{new(Bool), "maybe"},
+		{new(Time), 5},
+		{new(Time), "yesterday"},
+		{new(Int8), 128},
+		{new(Uint32), -1},
+	}
+	for _, tt := range tests {
+		if err := tt.p.UnmarshalYAML(yamlNode(tt.node)); err == nil {
+			t.Errorf("%T.UnmarshalYAML(%#v) = %+v, want error", tt.p, tt.node, elem(tt.p))
+		}
+		if v := tt.p.(interface{ IsValid() bool }); v.IsValid() {
+			t.Errorf("%T.UnmarshalYAML(%#v) left a valid value", tt.p, tt.node)
+		}
+	}
+}